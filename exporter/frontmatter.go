@@ -0,0 +1,81 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// FrontMatterFormat はフロントマターの形式です。
+type FrontMatterFormat int
+
+const (
+	// FrontMatterYAML は「---」で囲んだYAML形式です（Hugo・Jekyll）。
+	FrontMatterYAML FrontMatterFormat = iota
+	// FrontMatterTOML は「+++」で囲んだTOML形式です（Hugo）。
+	FrontMatterTOML
+)
+
+// frontMatterField はフロントマターの1項目です
+type frontMatterField struct {
+	key   string
+	value any // string, time.Time, []string
+}
+
+// writeFrontMatter はフロントマターを書き出します。
+// 空の項目は出力しません。
+func writeFrontMatter(b *bytes.Buffer, format FrontMatterFormat, fields []frontMatterField) {
+	delimiter, separator := "---", ": "
+	if format == FrontMatterTOML {
+		delimiter, separator = "+++", " = "
+	}
+
+	b.WriteString(delimiter + "\n")
+	for _, field := range fields {
+		value := frontMatterValue(field.value)
+		if value == "" {
+			continue
+		}
+		b.WriteString(field.key + separator + value + "\n")
+	}
+	b.WriteString(delimiter + "\n")
+}
+
+// frontMatterValue は値をYAML・TOMLの両方で有効な表記に変換します。
+// 文字列はJSON形式でエスケープした二重引用符の文字列、日時はRFC 3339形式、配列はフロー形式で出力します。
+func frontMatterValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		if t == "" {
+			return ""
+		}
+		return quoteString(t)
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	case []string:
+		if len(t) == 0 {
+			return ""
+		}
+		quoted := make([]string, len(t))
+		for i, s := range t {
+			quoted[i] = quoteString(s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	}
+	return ""
+}
+
+// quoteString は文字列を二重引用符で囲み、JSONと同じ規則でエスケープします
+func quoteString(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return `""`
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
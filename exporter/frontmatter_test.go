@@ -0,0 +1,46 @@
+package exporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestFrontMatterValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"文字列", "タイトル", `"タイトル"`},
+		{"エスケープ", "a\"b\\c\nd<e>", `"a\"b\\c\nd<e>"`},
+		{"空文字列", "", ""},
+		{"日時", time.Date(2024, 5, 22, 12, 39, 1, 0, time.UTC), "2024-05-22T12:39:01Z"},
+		{"ゼロ値の日時", time.Time{}, ""},
+		{"配列", []string{"a", "b"}, `["a", "b"]`},
+		{"空の配列", []string(nil), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := frontMatterValue(tt.value); got != tt.want {
+				t.Errorf("frontMatterValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFrontMatter(t *testing.T) {
+	fields := []frontMatterField{{"title", "t"}, {"author", ""}, {"tags", []string{"x"}}}
+
+	var yaml bytes.Buffer
+	writeFrontMatter(&yaml, FrontMatterYAML, fields)
+	if want := "---\ntitle: \"t\"\ntags: [\"x\"]\n---\n"; yaml.String() != want {
+		t.Errorf("YAML = %q, want %q", yaml.String(), want)
+	}
+
+	var toml bytes.Buffer
+	writeFrontMatter(&toml, FrontMatterTOML, fields)
+	if want := "+++\ntitle = \"t\"\ntags = [\"x\"]\n+++\n"; toml.String() != want {
+		t.Errorf("TOML = %q, want %q", toml.String(), want)
+	}
+}
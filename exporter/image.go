@@ -0,0 +1,137 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// ImageFetcher はページバンドルに配置する画像を取得するためのインターフェースです。
+type ImageFetcher interface {
+	// FetchImage は画像URLの内容を返します。呼び出し元が返り値をCloseします。
+	FetchImage(ctx context.Context, imageURL string) (io.ReadCloser, error)
+}
+
+// HTTPImageFetcher はHTTPで画像を取得するImageFetcherです。
+type HTTPImageFetcher struct {
+	Client *http.Client // nilの場合はhttp.DefaultClientを使用します
+}
+
+// FetchImage は画像URLにGETリクエストを送り、レスポンスの本文を返します。
+func (f *HTTPImageFetcher) FetchImage(ctx context.Context, imageURL string) (io.ReadCloser, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", imageURL, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// bundleImageNames は記事内の画像URLとページバンドル内のファイル名の対応を返します。
+// 対象はhttp/httpsの画像で、ファイル名が重複する場合は「-2」のような連番を付与します。
+func bundleImageNames(post *models.BlogPost) map[string]string {
+	var urls []string
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(post.Content)); err == nil {
+		doc.Find("img[src]").Each(func(i int, s *goquery.Selection) {
+			urls = append(urls, strings.TrimSpace(s.AttrOr("src", "")))
+		})
+	}
+	urls = append(urls, post.FirstImage)
+
+	names := make(map[string]string)
+	used := map[string]bool{bundleIndexName: true}
+	for _, rawURL := range urls {
+		if _, ok := names[rawURL]; ok {
+			continue
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+
+		name := strings.Trim(slugUnsafeRe.ReplaceAllString(path.Base(u.Path), "-"), "-.")
+		if name == "" {
+			name = "image"
+		}
+		ext := path.Ext(name)
+		stem := strings.TrimSuffix(name, ext)
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s-%d%s", stem, n, ext)
+		}
+		used[name] = true
+		names[rawURL] = name
+	}
+	return names
+}
+
+// saveImages は画像を取得してdirに保存します
+func saveImages(ctx context.Context, fetcher ImageFetcher, images map[string]string, dir string) error {
+	for imageURL, name := range images {
+		if err := saveImage(ctx, fetcher, imageURL, filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("画像 %s の保存に失敗しました: %w", imageURL, err)
+		}
+	}
+	return nil
+}
+
+// saveImage は1つの画像を取得してファイルに保存します
+func saveImage(ctx context.Context, fetcher ImageFetcher, imageURL, filename string) error {
+	body, err := fetcher.FetchImage(ctx, imageURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// replaceImageURLs はHTMLの本文の画像URLをページバンドル内のファイル名に書き換えます
+func replaceImageURLs(content string, images map[string]string) string {
+	if len(images) == 0 {
+		return content
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+	doc.Find("img[src]").Each(func(i int, s *goquery.Selection) {
+		if name, ok := images[strings.TrimSpace(s.AttrOr("src", ""))]; ok {
+			s.SetAttr("src", name)
+			s.RemoveAttr("srcset")
+		}
+	})
+	body, err := doc.Find("body").Html()
+	if err != nil {
+		return content
+	}
+	return body
+}
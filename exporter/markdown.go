@@ -0,0 +1,178 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// 代表的な静的サイトジェネレーターの出力先のパス
+const (
+	// LayoutHugo はHugoのcontentディレクトリ向けのパスです。
+	LayoutHugo = "content/posts/:year/:month/:slug.md"
+	// LayoutJekyll はJekyllの_postsディレクトリ向けのパスです。
+	LayoutJekyll = "_posts/:year-:month-:day-:slug.md"
+)
+
+// ページバンドルの本文のファイル名
+const bundleIndexName = "index.md"
+
+// スラッグとして使用できない文字の連続
+var slugUnsafeRe = regexp.MustCompile(`[\s/\\:*?"<>|#%]+`)
+
+// MarkdownExporter はBlogPostをフロントマター付きのMarkdownファイルとして書き出します。
+type MarkdownExporter struct {
+	format   FrontMatterFormat
+	layout   string
+	bundle   bool
+	htmlBody bool
+	fetcher  ImageFetcher
+}
+
+// New は新しいMarkdownExporterを作成します。
+// 既定ではYAML形式のフロントマターを使用し、LayoutHugoのパスに出力します。
+func New(opts ...Option) *MarkdownExporter {
+	e := &MarkdownExporter{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Path は記事の出力先の相対パス（スラッシュ区切り）を返します。
+// ページバンドル形式の場合は「<スラッグ>/index.md」になります。
+// 作成日が不明な場合、日付は「0000」「00」に置き換えられます。
+func (e *MarkdownExporter) Path(post *models.BlogPost) string {
+	layout := e.layout
+	if layout == "" {
+		layout = LayoutHugo
+	}
+
+	year, month, day := "0000", "00", "00"
+	if !post.CreatedAt.IsZero() {
+		year = post.CreatedAt.Format("2006")
+		month = post.CreatedAt.Format("01")
+		day = post.CreatedAt.Format("02")
+	}
+	p := strings.NewReplacer(
+		":year", year,
+		":month", month,
+		":day", day,
+		":slug", postSlug(post),
+	).Replace(layout)
+
+	if e.bundle {
+		p = strings.TrimSuffix(p, path.Ext(p)) + "/" + bundleIndexName
+	}
+	return path.Clean(p)
+}
+
+// Render は記事をフロントマター付きのMarkdownに変換します。
+// ページバンドル形式の場合、画像URLはバンドル内のファイル名に書き換えられます。
+func (e *MarkdownExporter) Render(post *models.BlogPost) ([]byte, error) {
+	var images map[string]string
+	if e.bundle {
+		images = bundleImageNames(post)
+	}
+	return e.render(post, images)
+}
+
+// Export は記事をdir以下のPathの位置に書き出し、書き出したファイルのパスを返します。
+// ページバンドル形式の場合は記事内の画像を取得し、index.mdと同じディレクトリに保存します。
+func (e *MarkdownExporter) Export(ctx context.Context, post *models.BlogPost, dir string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var images map[string]string
+	if e.bundle {
+		images = bundleImageNames(post)
+	}
+	data, err := e.render(post, images)
+	if err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(dir, filepath.FromSlash(e.Path(post)))
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", fmt.Errorf("ディレクトリ %s を作成できません: %w", filepath.Dir(filename), err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return "", fmt.Errorf("ファイル %s に書き込めません: %w", filename, err)
+	}
+
+	if len(images) > 0 {
+		fetcher := e.fetcher
+		if fetcher == nil {
+			fetcher = &HTTPImageFetcher{}
+		}
+		if err := saveImages(ctx, fetcher, images, filepath.Dir(filename)); err != nil {
+			return filename, err
+		}
+	}
+
+	return filename, nil
+}
+
+// render はフロントマターと本文を書き出します（imagesは元のURLからバンドル内のファイル名への対応）
+func (e *MarkdownExporter) render(post *models.BlogPost, images map[string]string) ([]byte, error) {
+	imageURL := func(u string) string {
+		if name, ok := images[u]; ok {
+			return name
+		}
+		return u
+	}
+
+	var b bytes.Buffer
+	writeFrontMatter(&b, e.format, []frontMatterField{
+		{"title", post.Title},
+		{"subtitle", post.Subtitle},
+		{"date", post.CreatedAt},
+		{"lastmod", post.UpdatedAt},
+		{"slug", postSlug(post)},
+		{"author", post.Author},
+		{"description", post.Summary},
+		{"tags", post.Tags},
+		{"categories", post.Categories},
+		{"image", imageURL(post.FirstImage)},
+	})
+	b.WriteString("\n")
+
+	body := post.Content
+	if e.htmlBody {
+		body = replaceImageURLs(body, images)
+	} else {
+		converter := &markdownConverter{imageURL: imageURL}
+		md, err := converter.convertToMarkdown(post.Content)
+		if err != nil {
+			return nil, err
+		}
+		body = md
+	}
+	b.WriteString(strings.TrimSpace(body))
+	b.WriteString("\n")
+
+	return b.Bytes(), nil
+}
+
+// postSlug はファイル名に使用するスラッグを返します。
+// スラッグがない場合はURLの末尾を使用し、ParseFileが設定するファイル名の拡張子は除去します。
+func postSlug(post *models.BlogPost) string {
+	slug := post.Slug
+	if slug == "" {
+		slug = path.Base(strings.TrimSuffix(post.URL, "/"))
+	}
+	slug = strings.TrimSuffix(slug, path.Ext(slug))
+	slug = strings.Trim(slugUnsafeRe.ReplaceAllString(slug, "-"), "-.")
+	if slug == "" {
+		return "post"
+	}
+	return slug
+}
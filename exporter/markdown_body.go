@@ -0,0 +1,341 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// 変換中の文字列で使用する制御文字
+const (
+	mdIndent    = "\x00" // 入れ子のリストのインデント（行頭の空白の除去対象から外す）
+	mdHardBreak = "\x01" // brによる改行（行末の空白2つに置き換える）
+)
+
+var (
+	// 連続する空白
+	mdSpaceRe = regexp.MustCompile(`\s+`)
+	// 3行以上連続する改行
+	mdBlankLinesRe = regexp.MustCompile(`\n{3,}`)
+	// Markdownの記法として解釈される文字
+	mdEscaper = strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`,
+		`[`, `\[`, `]`, `\]`, `<`, `&lt;`, `>`, `&gt;`,
+	)
+)
+
+// HTMLとして残す要素（Markdownに対応する記法がないもの）
+var mdRawElements = map[string]bool{
+	"table": true, "iframe": true, "video": true, "audio": true,
+}
+
+// 段落として扱うブロック要素
+var mdBlockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true, "footer": true,
+	"main": true, "aside": true, "figure": true, "figcaption": true, "center": true,
+	"dl": true, "dt": true, "dd": true, "address": true, "details": true, "summary": true,
+}
+
+// markdownConverter はHTMLの本文をMarkdownに変換します
+type markdownConverter struct {
+	imageURL func(string) string // 画像URLの書き換え（ページバンドル用）
+}
+
+// convertToMarkdown はHTMLの本文をMarkdownに変換します
+func (c *markdownConverter) convertToMarkdown(content string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", fmt.Errorf("本文のHTMLのパースに失敗しました: %w", err)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(c.render(n))
+	}
+	md := normalizeMarkdown(b.String())
+	return strings.ReplaceAll(md, mdIndent, " "), nil
+}
+
+// render はノードをMarkdownに変換します
+func (c *markdownConverter) render(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return mdEscaper.Replace(mdSpaceRe.ReplaceAllString(n.Data, " "))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	// 数式（$・$$で囲んだTeX）はエスケープせずにそのまま出力する
+	if hasClass(n, "math") {
+		if hasClass(n, "math-display") {
+			return "\n\n" + strings.TrimSpace(textContent(n)) + "\n\n"
+		}
+		return strings.TrimSpace(textContent(n))
+	}
+
+	switch n.Data {
+	case "script", "style", "noscript", "template":
+		return ""
+	case "br":
+		return mdHardBreak + "\n"
+	case "hr":
+		return "\n\n---\n\n"
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(n.Data[1] - '0')
+		text := strings.TrimSpace(singleLine(c.children(n)))
+		if text == "" {
+			return ""
+		}
+		return "\n\n" + strings.Repeat("#", level) + " " + text + "\n\n"
+	case "strong", "b":
+		return wrapInline(c.children(n), "**")
+	case "em", "i":
+		return wrapInline(c.children(n), "*")
+	case "del", "s", "strike":
+		return wrapInline(c.children(n), "~~")
+	case "code":
+		return codeSpan(textContent(n))
+	case "pre":
+		return "\n\n```" + codeFenceInfo(n) + "\n" + strings.TrimRight(textContent(n), "\n") + "\n```\n\n"
+	case "a":
+		return c.renderLink(n)
+	case "img":
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		if c.imageURL != nil {
+			src = c.imageURL(src)
+		}
+		return "![" + mdEscaper.Replace(attr(n, "alt")) + "](" + markdownURL(src) + ")"
+	case "blockquote":
+		return "\n\n" + prefixLines(normalizeMarkdown(c.children(n)), "> ", "> ") + "\n\n"
+	case "ul", "ol":
+		return c.renderList(n)
+	}
+
+	if mdRawElements[n.Data] {
+		var b bytes.Buffer
+		if err := html.Render(&b, n); err != nil {
+			return ""
+		}
+		return "\n\n" + b.String() + "\n\n"
+	}
+	if mdBlockElements[n.Data] {
+		return "\n\n" + c.children(n) + "\n\n"
+	}
+	return c.children(n)
+}
+
+// children は子ノードをMarkdownに変換して連結します
+func (c *markdownConverter) children(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.render(child))
+	}
+	return b.String()
+}
+
+// renderLink はリンクをMarkdownに変換します
+func (c *markdownConverter) renderLink(n *html.Node) string {
+	text := strings.TrimSpace(singleLine(c.children(n)))
+	href := attr(n, "href")
+	switch {
+	case href == "" || strings.HasPrefix(href, "javascript:"):
+		return text
+	case text == "":
+		return "<" + href + ">"
+	}
+	return "[" + text + "](" + markdownURL(href) + ")"
+}
+
+// renderList は箇条書き・番号付きリストをMarkdownに変換します
+func (c *markdownConverter) renderList(n *html.Node) string {
+	var items []string
+	number := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		number = start
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.Data != "li" {
+			continue
+		}
+		marker := "- "
+		if n.Data == "ol" {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		item := normalizeMarkdown(c.children(child))
+		// 2行目以降はマーカーの幅だけインデントする（入れ子のリストを含む）
+		items = append(items, prefixLines(item, marker, strings.Repeat(mdIndent, len(marker))))
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(items, "\n") + "\n\n"
+}
+
+// normalizeMarkdown は行頭・行末の空白と連続する空行を整理します。
+// コードブロック内の行はそのまま残します。
+func normalizeMarkdown(md string) string {
+	lines := strings.Split(md, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := trimMarkdownLine(line)
+		if strings.HasPrefix(strings.TrimLeft(trimmed, mdIndent+"> "), "```") {
+			inFence = !inFence
+			lines[i] = trimmed
+			continue
+		}
+		if inFence {
+			continue
+		}
+		// 段落の末尾以外のbrは行末の空白2つ（ハードブレーク）にする
+		hardBreak := strings.Contains(line, mdHardBreak) && trimmed != ""
+		if hardBreak && i+1 < len(lines) && trimMarkdownLine(lines[i+1]) != "" {
+			trimmed += "  "
+		}
+		lines[i] = trimmed
+	}
+	md = strings.Join(lines, "\n")
+	md = mdBlankLinesRe.ReplaceAllString(md, "\n\n")
+	return strings.Trim(md, "\n")
+}
+
+// trimMarkdownLine は行頭・行末の空白（ノーブレークスペースを含む）とbrの印を除去します。
+// 全角空白による字下げは残します。
+func trimMarkdownLine(line string) string {
+	return strings.Trim(line, " \t\u00a0"+mdHardBreak)
+}
+
+// prefixLines は1行目にfirst、2行目以降にrestを付与します（空行にはrestの末尾の空白を付けない）
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" {
+			prefix = strings.TrimRight(prefix, " "+mdIndent)
+		}
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapInline は強調などの記号でテキストを囲みます（前後の空白は記号の外に出す）
+func wrapInline(s, mark string) string {
+	trimmed := strings.TrimSpace(singleLine(s))
+	if trimmed == "" {
+		return s
+	}
+	leading := s[:len(s)-len(strings.TrimLeft(s, " "))]
+	trailing := s[len(strings.TrimRight(s, " ")):]
+	return leading + mark + trimmed + mark + trailing
+}
+
+// singleLine は改行を空白に置き換えます
+func singleLine(s string) string {
+	s = strings.ReplaceAll(s, mdHardBreak, "")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// markdownURL はMarkdownのリンク先として使用できるように空白と括弧をエスケープします
+func markdownURL(u string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(u)
+}
+
+// textContent はノード内のテキストを連結して返します
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.Data == "br" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+// codeSpan はテキストをインラインコードにします。
+// バッククォートはエスケープできないため、テキスト内のどの連続より長いバッククォートで囲み、
+// 先頭・末尾がバッククォートの場合や前後が空白の場合は空白を補います。
+func codeSpan(text string) string {
+	if text == "" {
+		return ""
+	}
+	fence := strings.Repeat("`", longestBacktickRun(text)+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") ||
+		(strings.HasPrefix(text, " ") && strings.HasSuffix(text, " ") && strings.TrimSpace(text) != "") {
+		text = " " + text + " "
+	}
+	return fence + text + fence
+}
+
+// longestBacktickRun はテキスト内で連続するバッククォートの最大の個数を返します
+func longestBacktickRun(text string) int {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// codeFenceInfo はpre内のcode要素のclass属性（language-go）とdata-filename属性から
+// コードブロックの言語とファイル名（go:main.go）を返します
+func codeFenceInfo(pre *html.Node) string {
+	for child := pre.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.Data != "code" {
+			continue
+		}
+		lang := ""
+		for _, class := range strings.Fields(attr(child, "class")) {
+			if l, ok := strings.CutPrefix(class, "language-"); ok {
+				lang = l
+				break
+			}
+		}
+		if filename := attr(child, "data-filename"); filename != "" {
+			return lang + ":" + filename
+		}
+		return lang
+	}
+	return ""
+}
+
+// hasClass はノードのclass属性に指定したクラスが含まれるかを判定します
+func hasClass(n *html.Node, class string) bool {
+	return slices.Contains(strings.Fields(attr(n, "class")), class)
+}
+
+// attr はノードの属性値を返します
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
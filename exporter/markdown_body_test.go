@@ -0,0 +1,77 @@
+package exporter
+
+import "testing"
+
+func TestConvertToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "見出しと段落",
+			html: `<h2>見出し</h2><p>一段落目</p><p>二段落目</p>`,
+			want: "## 見出し\n\n一段落目\n\n二段落目",
+		},
+		{
+			name: "brによる改行",
+			html: `<div>一行目<br>二行目<br><br>次の段落<br></div>`,
+			want: "一行目  \n二行目\n\n次の段落",
+		},
+		{
+			name: "強調とリンクと画像",
+			html: `<p><strong>太字</strong>と<em> 斜体 </em>と<a href="https://example.com/a b">リンク</a>と<img src="https://example.com/x.jpg" alt="画像"></p>`,
+			want: "**太字**と *斜体* と[リンク](https://example.com/a%20b)と![画像](https://example.com/x.jpg)",
+		},
+		{
+			name: "入れ子のリスト",
+			html: `<ul><li>項目1<ul><li>子項目</li></ul></li><li>項目2</li></ul><ol start="3"><li>三</li><li>四</li></ol>`,
+			want: "- 項目1\n\n  - 子項目\n- 項目2\n\n3. 三\n4. 四",
+		},
+		{
+			name: "引用とコード",
+			html: "<blockquote><p>引用1</p><p>引用2</p></blockquote><pre><code>  func main() {\n  }</code></pre><p><code>a*b</code></p>",
+			want: "> 引用1\n>\n> 引用2\n\n```\n  func main() {\n  }\n```\n\n`a*b`",
+		},
+		{
+			name: "バッククォートを含むインラインコード",
+			html: "<p><code>a`b</code> <code>``x``</code> <code>`</code> <code> c </code></p>",
+			want: "``a`b`` ``` ``x`` ``` `` ` `` `  c  `",
+		},
+		{
+			name: "言語とファイル名付きのコードブロック",
+			html: "<pre><code class=\"language-go\">fmt.Println(1)</code></pre><pre><code class=\"language-sh\" data-filename=\"run.sh\">go test ./...</code></pre>",
+			want: "```go\nfmt.Println(1)\n```\n\n```sh:run.sh\ngo test ./...\n```",
+		},
+		{
+			name: "数式はエスケープしない",
+			html: `<p>式<span class="math math-inline">$a_1 * b$</span>です</p><div class="math math-display">$$
+\sum_{i=1}^n x_i
+$$</div>`,
+			want: "式$a_1 * b$です\n\n$$\n\\sum_{i=1}^n x_i\n$$",
+		},
+		{
+			name: "Markdownの記法のエスケープとノーブレークスペース",
+			html: `<p>*注意* [1] &lt;b&gt;</p><p>&nbsp;</p><p>　字下げ</p>`,
+			want: "\\*注意\\* \\[1\\] &lt;b&gt;\n\n　字下げ",
+		},
+		{
+			name: "表はHTMLのまま",
+			html: `<p>前</p><table><tbody><tr><td>セル</td></tr></tbody></table><script>alert(1)</script>`,
+			want: "前\n\n<table><tbody><tr><td>セル</td></tr></tbody></table>",
+		},
+	}
+
+	c := &markdownConverter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.convertToMarkdown(tt.html)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("convertToMarkdown() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func testPost() *models.BlogPost {
+	return &models.BlogPost{
+		Title:      `月山に"思い"を馳せる`,
+		Content:    `<p>本文<img src="https://pds.exblog.jp/pds/1/201109/12/14/photo.jpg"></p><p><img src="https://example.com/b/photo.jpg"></p>`,
+		Summary:    "要約",
+		Tags:       []string{"山形", "月山"},
+		Categories: []string{"日記"},
+		CreatedAt:  time.Date(2011, 9, 12, 23, 31, 0, 0, time.FixedZone("JST", 9*3600)),
+		Slug:       "16274503.html",
+		FirstImage: "https://pds.exblog.jp/pds/1/201109/12/14/photo.jpg",
+	}
+}
+
+func TestPath(t *testing.T) {
+	post := testPost()
+	tests := []struct {
+		name string
+		opts []Option
+		post *models.BlogPost
+		want string
+	}{
+		{"既定（Hugo）", nil, post, "content/posts/2011/09/16274503.md"},
+		{"Jekyll", []Option{WithLayout(LayoutJekyll)}, post, "_posts/2011-09-12-16274503.md"},
+		{"ページバンドル", []Option{WithPageBundle()}, post, "content/posts/2011/09/16274503/index.md"},
+		{"日付とスラッグなし", nil, &models.BlogPost{URL: "https://ameblo.jp/user/entry-123.html"}, "content/posts/0000/00/entry-123.md"},
+		{"スラッグに使えない文字", []Option{WithLayout("posts/:slug.md")}, &models.BlogPost{Slug: "a b/c"}, "posts/a-b-c.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.opts...).Path(tt.post); got != tt.want {
+				t.Errorf("Path() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "YAML",
+			want: `---
+title: "月山に\"思い\"を馳せる"
+date: 2011-09-12T23:31:00+09:00
+slug: "16274503"
+description: "要約"
+tags: ["山形", "月山"]
+categories: ["日記"]
+image: "https://pds.exblog.jp/pds/1/201109/12/14/photo.jpg"
+---
+
+本文![](https://pds.exblog.jp/pds/1/201109/12/14/photo.jpg)
+
+![](https://example.com/b/photo.jpg)
+`,
+		},
+		{
+			name: "TOMLとページバンドル",
+			opts: []Option{WithFrontMatter(FrontMatterTOML), WithPageBundle()},
+			want: `+++
+title = "月山に\"思い\"を馳せる"
+date = 2011-09-12T23:31:00+09:00
+slug = "16274503"
+description = "要約"
+tags = ["山形", "月山"]
+categories = ["日記"]
+image = "photo.jpg"
++++
+
+本文![](photo.jpg)
+
+![](photo-2.jpg)
+`,
+		},
+		{
+			name: "HTMLのまま出力",
+			opts: []Option{WithHTMLBody(), WithPageBundle()},
+			want: `---
+title: "月山に\"思い\"を馳せる"
+date: 2011-09-12T23:31:00+09:00
+slug: "16274503"
+description: "要約"
+tags: ["山形", "月山"]
+categories: ["日記"]
+image: "photo.jpg"
+---
+
+<p>本文<img src="photo.jpg"/></p><p><img src="photo-2.jpg"/></p>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.opts...).Render(testPost())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeFetcher は画像URLをそのまま内容として返すImageFetcherです
+type fakeFetcher struct {
+	err error
+}
+
+func (f *fakeFetcher) FetchImage(ctx context.Context, imageURL string) (io.ReadCloser, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return io.NopCloser(strings.NewReader(imageURL)), nil
+}
+
+func TestExportPageBundle(t *testing.T) {
+	dir := t.TempDir()
+	e := New(WithPageBundle(), WithImageFetcher(&fakeFetcher{}))
+
+	filename, err := e.Export(context.Background(), testPost(), dir)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	bundle := filepath.Join(dir, "content", "posts", "2011", "09", "16274503")
+	if filename != filepath.Join(bundle, "index.md") {
+		t.Errorf("Export() = %q", filename)
+	}
+
+	for name, want := range map[string]string{
+		"photo.jpg":   "https://pds.exblog.jp/pds/1/201109/12/14/photo.jpg",
+		"photo-2.jpg": "https://example.com/b/photo.jpg",
+	} {
+		data, err := os.ReadFile(filepath.Join(bundle, name))
+		if err != nil {
+			t.Errorf("image %s: %v", name, err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("image %s = %q, want %q", name, data, want)
+		}
+	}
+
+	fetchErr := errors.New("not found")
+	_, err = New(WithPageBundle(), WithImageFetcher(&fakeFetcher{err: fetchErr})).Export(context.Background(), testPost(), t.TempDir())
+	if !errors.Is(err, fetchErr) {
+		t.Errorf("Export() error = %v, want %v", err, fetchErr)
+	}
+}
+
+func TestExportWithCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := New().Export(ctx, testPost(), t.TempDir()); err == nil {
+		t.Error("Export() with cancelled context should return error")
+	}
+}
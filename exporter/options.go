@@ -0,0 +1,42 @@
+package exporter
+
+// Option はMarkdownExporterの動作を変更するための関数型オプションです。
+type Option func(*MarkdownExporter)
+
+// WithFrontMatter はフロントマターの形式（YAMLまたはTOML）を設定します。
+func WithFrontMatter(format FrontMatterFormat) Option {
+	return func(e *MarkdownExporter) {
+		e.format = format
+	}
+}
+
+// WithLayout は出力先のパスの形式を設定します。
+// 「:year」「:month」「:day」「:slug」は記事の作成日とスラッグに置き換えられます。
+func WithLayout(layout string) Option {
+	return func(e *MarkdownExporter) {
+		e.layout = layout
+	}
+}
+
+// WithPageBundle はページバンドル形式（記事ごとのディレクトリにindex.mdと画像を配置する）で出力します。
+// 本文とフロントマターの画像URLはバンドル内のファイル名に書き換えられます。
+func WithPageBundle() Option {
+	return func(e *MarkdownExporter) {
+		e.bundle = true
+	}
+}
+
+// WithImageFetcher はページバンドルに配置する画像の取得方法を設定します。
+// 指定しない場合はHTTPで取得します。
+func WithImageFetcher(fetcher ImageFetcher) Option {
+	return func(e *MarkdownExporter) {
+		e.fetcher = fetcher
+	}
+}
+
+// WithHTMLBody は本文をMarkdownに変換せず、HTMLのまま出力します。
+func WithHTMLBody() Option {
+	return func(e *MarkdownExporter) {
+		e.htmlBody = true
+	}
+}
@@ -0,0 +1,470 @@
+package exporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// WXRのバージョン
+const wxrVersion = "1.2"
+
+// WXRの日時の形式（wp:post_date など）
+const wxrDateFormat = "2006-01-02 15:04:05"
+
+// WXRSite はWXRファイルに出力するサイトの情報です。
+type WXRSite struct {
+	Title         string // サイト名
+	URL           string // サイトのURL
+	Description   string // サイトの説明
+	Language      string // 言語（空の場合はja）
+	DefaultAuthor string // 著者が不明な記事の投稿者のログイン名（空の場合はadmin）
+	Status        string // 公開済みの記事の公開状態（publish, private など。空の場合はpublish。未公開の記事は常にdraft）
+}
+
+// WriteWXR は記事の一覧をWordPressのインポーターで読み込めるWXR（WordPress eXtended RSS）形式で書き出します。
+// カテゴリ・タグ・著者・コメントに加え、本文中の画像とアイキャッチ画像を添付ファイルとして出力します。
+// 添付ファイルはインポート時に「添付ファイルをダウンロードしてインポートする」を選択すると取り込まれます。
+func WriteWXR(w io.Writer, site WXRSite, posts []*models.BlogPost) error {
+	doc := buildWXR(site, posts)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("WXRの書き込みに失敗しました: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("WXRの書き込みに失敗しました: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("WXRの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// buildWXR はWXRの文書を組み立てます
+func buildWXR(site WXRSite, posts []*models.BlogPost) wxrRSS {
+	language := site.Language
+	if language == "" {
+		language = "ja"
+	}
+	status := site.Status
+	if status == "" {
+		status = "publish"
+	}
+	defaultAuthor := site.DefaultAuthor
+	if defaultAuthor == "" {
+		defaultAuthor = "admin"
+	}
+
+	channel := wxrChannel{
+		Title:       site.Title,
+		Link:        site.URL,
+		Description: site.Description,
+		PubDate:     time.Now().Format(time.RFC1123Z),
+		Language:    language,
+		WXRVersion:  wxrVersion,
+		BaseSiteURL: site.URL,
+		BaseBlogURL: site.URL,
+	}
+
+	authors := newWXRAuthors(defaultAuthor)
+	categories := newWXRTerms()
+	tags := newWXRTerms()
+	var attachments []wxrItem
+
+	// 記事のIDは1から順に、添付ファイルのIDは記事のIDの後に割り当てる
+	nextID := len(posts) + 1
+	commentID := 1
+	for i, post := range posts {
+		postID := i + 1
+		item := wxrItem{
+			Title:         post.Title,
+			Link:          post.URL,
+			PubDate:       wxrPubDate(post.CreatedAt),
+			Creator:       cdata(authors.login(post.Author)),
+			GUID:          wxrGUID{IsPermaLink: "false", Value: wxrPostGUID(site.URL, post, postID)},
+			Content:       cdata(post.Content),
+			Excerpt:       cdata(post.Summary),
+			PostID:        postID,
+			PostDate:      cdata(wxrLocalDate(post.CreatedAt)),
+			PostDateGMT:   cdata(wxrGMTDate(post.CreatedAt)),
+			PostModified:  cdata(wxrLocalDate(post.UpdatedAt)),
+			ModifiedGMT:   cdata(wxrGMTDate(post.UpdatedAt)),
+			CommentStatus: cdata("open"),
+			PingStatus:    cdata("open"),
+			PostName:      cdata(wxrPostName(post)),
+			Status:        cdata(wxrStatus(post, status)),
+			PostType:      cdata("post"),
+		}
+
+		for _, name := range post.Categories {
+			item.Categories = append(item.Categories, categories.add("category", name))
+		}
+		for _, name := range post.Tags {
+			item.Categories = append(item.Categories, tags.add("post_tag", name))
+		}
+
+		item.Comments, commentID = wxrComments(post.Comments, 0, commentID)
+
+		// 添付ファイル（URL順に並べて出力を安定させる）
+		images := bundleImageNames(post)
+		urls := make([]string, 0, len(images))
+		for u := range images {
+			urls = append(urls, u)
+		}
+		sort.Strings(urls)
+		for _, u := range urls {
+			attachmentID := nextID
+			nextID++
+			name := images[u]
+			attachments = append(attachments, wxrItem{
+				Title:         strings.TrimSuffix(name, path.Ext(name)),
+				Link:          u,
+				PubDate:       item.PubDate,
+				Creator:       item.Creator,
+				GUID:          wxrGUID{IsPermaLink: "false", Value: u},
+				Content:       cdata(""),
+				Excerpt:       cdata(""),
+				PostID:        attachmentID,
+				PostDate:      item.PostDate,
+				PostDateGMT:   item.PostDateGMT,
+				PostModified:  item.PostModified,
+				ModifiedGMT:   item.ModifiedGMT,
+				CommentStatus: cdata("closed"),
+				PingStatus:    cdata("closed"),
+				PostName:      cdata(wxrSlug(strings.TrimSuffix(name, path.Ext(name)))),
+				Status:        cdata("inherit"),
+				PostParent:    postID,
+				PostType:      cdata("attachment"),
+				AttachmentURL: &wxrCDATA{Value: u},
+			})
+			if u == post.FirstImage {
+				item.PostMeta = append(item.PostMeta, wxrPostMeta{
+					Key:   cdata("_thumbnail_id"),
+					Value: cdata(fmt.Sprint(attachmentID)),
+				})
+			}
+		}
+
+		channel.Items = append(channel.Items, item)
+	}
+	channel.Items = append(channel.Items, attachments...)
+	channel.Authors = authors.list
+	// term_idはWordPressと同様にカテゴリ・タグで共通の連番にする
+	channel.Categories = categories.categories(1)
+	channel.Tags = tags.tags(len(channel.Categories) + 1)
+
+	return wxrRSS{
+		Version:   "2.0",
+		Excerpt:   "http://wordpress.org/export/" + wxrVersion + "/excerpt/",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		WFW:       "http://wellformedweb.org/CommentAPI/",
+		DC:        "http://purl.org/dc/elements/1.1/",
+		WP:        "http://wordpress.org/export/" + wxrVersion + "/",
+		Channel:   channel,
+	}
+}
+
+// wxrComments はコメントと返信をWXRのコメントに変換し、次に使用するコメントIDを返します
+func wxrComments(comments []models.Comment, parentID, nextID int) ([]wxrComment, int) {
+	var result []wxrComment
+	for _, c := range comments {
+		id := nextID
+		nextID++
+		result = append(result, wxrComment{
+			ID:        id,
+			Author:    cdata(c.Author),
+			AuthorURL: c.AuthorURL,
+			Date:      cdata(wxrLocalDate(c.CreatedAt)),
+			DateGMT:   cdata(wxrGMTDate(c.CreatedAt)),
+			Content:   cdata(c.Body),
+			Approved:  cdata("1"),
+			Type:      cdata("comment"),
+			Parent:    parentID,
+		})
+		var replies []wxrComment
+		replies, nextID = wxrComments(c.Replies, id, nextID)
+		result = append(result, replies...)
+	}
+	return result, nextID
+}
+
+// wxrAuthors は記事の著者をWordPressのユーザーとして管理します
+type wxrAuthors struct {
+	defaultLogin string
+	logins       map[string]string // 著者名→ログイン名
+	list         []wxrAuthor
+}
+
+func newWXRAuthors(defaultLogin string) *wxrAuthors {
+	return &wxrAuthors{defaultLogin: defaultLogin, logins: make(map[string]string)}
+}
+
+// login は著者名に対応するログイン名を返します。
+// ログイン名は英数字のみの著者名はそのまま、それ以外は「author1」のような連番にします。
+func (a *wxrAuthors) login(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = a.defaultLogin
+	}
+	if login, ok := a.logins[name]; ok {
+		return login
+	}
+
+	login := strings.ToLower(name)
+	if strings.IndexFunc(login, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.')
+	}) >= 0 {
+		login = fmt.Sprintf("author%d", len(a.list)+1)
+	}
+	a.logins[name] = login
+	a.list = append(a.list, wxrAuthor{
+		ID:          len(a.list) + 1,
+		Login:       cdata(login),
+		DisplayName: cdata(name),
+	})
+	return login
+}
+
+// wxrTerms はカテゴリ・タグを重複なく管理します
+type wxrTerms struct {
+	names []string
+	slugs map[string]string
+}
+
+func newWXRTerms() *wxrTerms {
+	return &wxrTerms{slugs: make(map[string]string)}
+}
+
+// add は用語を登録し、記事に付与するcategory要素を返します
+func (t *wxrTerms) add(domain, name string) wxrItemCategory {
+	slug, ok := t.slugs[name]
+	if !ok {
+		slug = wxrSlug(name)
+		t.slugs[name] = slug
+		t.names = append(t.names, name)
+	}
+	return wxrItemCategory{Domain: domain, Nicename: slug, Name: name}
+}
+
+// categories はfirstIDから順にterm_idを割り当てたカテゴリの一覧を返します
+func (t *wxrTerms) categories(firstID int) []wxrCategory {
+	var result []wxrCategory
+	for i, name := range t.names {
+		result = append(result, wxrCategory{
+			TermID:   firstID + i,
+			Nicename: cdata(t.slugs[name]),
+			Parent:   cdata(""),
+			Name:     cdata(name),
+		})
+	}
+	return result
+}
+
+// tags はfirstIDから順にterm_idを割り当てたタグの一覧を返します
+func (t *wxrTerms) tags(firstID int) []wxrTag {
+	var result []wxrTag
+	for i, name := range t.names {
+		result = append(result, wxrTag{
+			TermID: firstID + i,
+			Slug:   cdata(t.slugs[name]),
+			Name:   cdata(name),
+		})
+	}
+	return result
+}
+
+// wxrSlug はWordPressのsanitize_titleと同様に、小文字化して空白をハイフンに置き換え、
+// ASCII以外の文字を小文字のパーセントエンコードに変換します
+func wxrSlug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'):
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '.' || r == '/':
+			b.WriteByte('-')
+		case r > unicode.MaxASCII:
+			for _, c := range []byte(string(r)) {
+				fmt.Fprintf(&b, "%%%02x", c)
+			}
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// wxrPostName は記事のスラッグ（wp:post_name）を返します
+func wxrPostName(post *models.BlogPost) string {
+	slug := post.Slug
+	if slug == "" {
+		return ""
+	}
+	return wxrSlug(strings.TrimSuffix(slug, path.Ext(slug)))
+}
+
+// wxrPostGUID は記事のGUIDを返します（パーマリンクがない場合はサイトURLと記事IDから生成）
+func wxrPostGUID(siteURL string, post *models.BlogPost, postID int) string {
+	if post.URL != "" {
+		return post.URL
+	}
+	return fmt.Sprintf("%s/?p=%d", strings.TrimSuffix(siteURL, "/"), postID)
+}
+
+// wxrPubDate はRSSのpubDateの形式で日時を返します（ゼロ値の場合は空文字列）
+func wxrPubDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+// wxrLocalDate は記事のタイムゾーンでの日時を返します（ゼロ値の場合は空文字列）
+func wxrLocalDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(wxrDateFormat)
+}
+
+// wxrGMTDate はGMTでの日時を返します（ゼロ値の場合は空文字列）
+func wxrGMTDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(wxrDateFormat)
+}
+
+// wxrCDATA はCDATAセクションとして出力する文字列です
+type wxrCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+func cdata(s string) wxrCDATA {
+	return wxrCDATA{Value: s}
+}
+
+// 以下はWXRの要素の定義です
+
+type wxrRSS struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	Excerpt   string     `xml:"xmlns:excerpt,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	WFW       string     `xml:"xmlns:wfw,attr"`
+	DC        string     `xml:"xmlns:dc,attr"`
+	WP        string     `xml:"xmlns:wp,attr"`
+	Channel   wxrChannel `xml:"channel"`
+}
+
+type wxrChannel struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	PubDate     string        `xml:"pubDate"`
+	Language    string        `xml:"language"`
+	WXRVersion  string        `xml:"wp:wxr_version"`
+	BaseSiteURL string        `xml:"wp:base_site_url"`
+	BaseBlogURL string        `xml:"wp:base_blog_url"`
+	Authors     []wxrAuthor   `xml:"wp:author"`
+	Categories  []wxrCategory `xml:"wp:category"`
+	Tags        []wxrTag      `xml:"wp:tag"`
+	Items       []wxrItem     `xml:"item"`
+}
+
+type wxrAuthor struct {
+	ID          int      `xml:"wp:author_id"`
+	Login       wxrCDATA `xml:"wp:author_login"`
+	Email       wxrCDATA `xml:"wp:author_email"`
+	DisplayName wxrCDATA `xml:"wp:author_display_name"`
+	FirstName   wxrCDATA `xml:"wp:author_first_name"`
+	LastName    wxrCDATA `xml:"wp:author_last_name"`
+}
+
+type wxrCategory struct {
+	TermID   int      `xml:"wp:term_id"`
+	Nicename wxrCDATA `xml:"wp:category_nicename"`
+	Parent   wxrCDATA `xml:"wp:category_parent"`
+	Name     wxrCDATA `xml:"wp:cat_name"`
+}
+
+type wxrTag struct {
+	TermID int      `xml:"wp:term_id"`
+	Slug   wxrCDATA `xml:"wp:tag_slug"`
+	Name   wxrCDATA `xml:"wp:tag_name"`
+}
+
+type wxrGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type wxrItem struct {
+	Title         string            `xml:"title"`
+	Link          string            `xml:"link"`
+	PubDate       string            `xml:"pubDate"`
+	Creator       wxrCDATA          `xml:"dc:creator"`
+	GUID          wxrGUID           `xml:"guid"`
+	Description   string            `xml:"description"`
+	Content       wxrCDATA          `xml:"content:encoded"`
+	Excerpt       wxrCDATA          `xml:"excerpt:encoded"`
+	PostID        int               `xml:"wp:post_id"`
+	PostDate      wxrCDATA          `xml:"wp:post_date"`
+	PostDateGMT   wxrCDATA          `xml:"wp:post_date_gmt"`
+	PostModified  wxrCDATA          `xml:"wp:post_modified"`
+	ModifiedGMT   wxrCDATA          `xml:"wp:post_modified_gmt"`
+	CommentStatus wxrCDATA          `xml:"wp:comment_status"`
+	PingStatus    wxrCDATA          `xml:"wp:ping_status"`
+	PostName      wxrCDATA          `xml:"wp:post_name"`
+	Status        wxrCDATA          `xml:"wp:status"`
+	PostParent    int               `xml:"wp:post_parent"`
+	MenuOrder     int               `xml:"wp:menu_order"`
+	PostType      wxrCDATA          `xml:"wp:post_type"`
+	PostPassword  wxrCDATA          `xml:"wp:post_password"`
+	IsSticky      int               `xml:"wp:is_sticky"`
+	AttachmentURL *wxrCDATA         `xml:"wp:attachment_url,omitempty"`
+	Categories    []wxrItemCategory `xml:"category"`
+	PostMeta      []wxrPostMeta     `xml:"wp:postmeta"`
+	Comments      []wxrComment      `xml:"wp:comment"`
+}
+
+type wxrItemCategory struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",cdata"`
+}
+
+type wxrPostMeta struct {
+	Key   wxrCDATA `xml:"wp:meta_key"`
+	Value wxrCDATA `xml:"wp:meta_value"`
+}
+
+type wxrComment struct {
+	ID          int      `xml:"wp:comment_id"`
+	Author      wxrCDATA `xml:"wp:comment_author"`
+	AuthorEmail wxrCDATA `xml:"wp:comment_author_email"`
+	AuthorURL   string   `xml:"wp:comment_author_url"`
+	AuthorIP    wxrCDATA `xml:"wp:comment_author_IP"`
+	Date        wxrCDATA `xml:"wp:comment_date"`
+	DateGMT     wxrCDATA `xml:"wp:comment_date_gmt"`
+	Content     wxrCDATA `xml:"wp:comment_content"`
+	Approved    wxrCDATA `xml:"wp:comment_approved"`
+	Type        wxrCDATA `xml:"wp:comment_type"`
+	Parent      int      `xml:"wp:comment_parent"`
+	UserID      int      `xml:"wp:comment_user_id"`
+}
+
+// wxrStatus は記事の公開状態（wp:status）を返します。未公開の記事はdraftとします。
+func wxrStatus(post *models.BlogPost, published string) string {
+	if !post.Published {
+		return "draft"
+	}
+	return published
+}
@@ -0,0 +1,150 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestWriteWXR(t *testing.T) {
+	jst := time.FixedZone("JST", 9*3600)
+	posts := []*models.BlogPost{
+		{
+			Title:      "『ルーティーン』",
+			Author:     "まっく",
+			Content:    `<p>本文]]>続き<img src="https://stat.ameba.jp/user_images/a/o1.jpg"></p>`,
+			Summary:    "要約",
+			Categories: []string{"ブログ"},
+			Tags:       []string{"認知症", "Care Log"},
+			CreatedAt:  time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			Slug:       "12887862927.html",
+			URL:        "https://ameblo.jp/macb2b37/entry-12887862927.html",
+			FirstImage: "https://stat.ameba.jp/user_images/a/o1.jpg",
+			Published:  true,
+			Comments: []models.Comment{
+				{Author: "読者", AuthorURL: "https://example.com/", Body: "こんにちは", CreatedAt: time.Date(2025, 4, 14, 9, 0, 0, 0, jst),
+					Replies: []models.Comment{{Author: "まっく", Body: "ありがとう"}}},
+			},
+		},
+		{
+			Title:      "二つ目",
+			Content:    "<p>本文</p>",
+			Categories: []string{"ブログ"},
+		},
+	}
+
+	var b bytes.Buffer
+	if err := WriteWXR(&b, WXRSite{Title: "アルツフルデイズ", URL: "https://example.com"}, posts); err != nil {
+		t.Fatalf("WriteWXR() error = %v", err)
+	}
+	out := b.String()
+
+	// 整形式のXMLであること
+	dec := xml.NewDecoder(strings.NewReader(out))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v\n%s", err, out)
+		}
+	}
+
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`xmlns:wp="http://wordpress.org/export/1.2/"`,
+		`<wp:wxr_version>1.2</wp:wxr_version>`,
+		`<wp:author_login><![CDATA[author1]]></wp:author_login>`,
+		`<wp:author_display_name><![CDATA[まっく]]></wp:author_display_name>`,
+		`<wp:author_login><![CDATA[admin]]></wp:author_login>`,
+		`<wp:category_nicename><![CDATA[%e3%83%96%e3%83%ad%e3%82%b0]]></wp:category_nicename>`,
+		`<wp:tag_slug><![CDATA[care-log]]></wp:tag_slug>`,
+		`<category domain="post_tag" nicename="care-log"><![CDATA[Care Log]]></category>`,
+		`<pubDate>Sun, 13 Apr 2025 18:18:05 +0900</pubDate>`,
+		`<wp:post_date><![CDATA[2025-04-13 18:18:05]]></wp:post_date>`,
+		`<wp:post_date_gmt><![CDATA[2025-04-13 09:18:05]]></wp:post_date_gmt>`,
+		`<wp:post_name><![CDATA[12887862927]]></wp:post_name>`,
+		`<wp:status><![CDATA[publish]]></wp:status>`,
+		`<wp:status><![CDATA[draft]]></wp:status>`,
+		`<![CDATA[<p>本文]]]]><![CDATA[>続き`,
+		`<wp:comment_parent>1</wp:comment_parent>`,
+		`<wp:comment_content><![CDATA[ありがとう]]></wp:comment_content>`,
+		`<wp:post_type><![CDATA[attachment]]></wp:post_type>`,
+		`<wp:attachment_url><![CDATA[https://stat.ameba.jp/user_images/a/o1.jpg]]></wp:attachment_url>`,
+		`<wp:post_parent>1</wp:post_parent>`,
+		`<wp:meta_value><![CDATA[3]]></wp:meta_value>`,
+		`<guid isPermaLink="false">https://example.com/?p=2</guid>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WXR should contain %s", want)
+		}
+	}
+
+	// カテゴリは重複して出力しない
+	if n := strings.Count(out, "<wp:cat_name>"); n != 1 {
+		t.Errorf("wp:category count = %d, want 1", n)
+	}
+	// term_idはカテゴリ・タグで重複しない
+	for id, want := range []string{"ブログ", "認知症", "Care Log"} {
+		term := fmt.Sprintf("<wp:term_id>%d</wp:term_id>", id+1)
+		if n := strings.Count(out, term); n != 1 {
+			t.Errorf("%s count = %d, want 1 (%s)", term, n, want)
+		}
+	}
+	// 添付ファイル以外の記事にattachment_urlを出力しない
+	if n := strings.Count(out, "<wp:attachment_url>"); n != 1 {
+		t.Errorf("wp:attachment_url count = %d, want 1", n)
+	}
+}
+
+func TestWXRStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		site      WXRSite
+		published bool
+		want      string
+	}{
+		{"公開済み", WXRSite{}, true, "publish"},
+		{"公開済み（サイトの公開状態を指定）", WXRSite{Status: "private"}, true, "private"},
+		{"未公開", WXRSite{}, false, "draft"},
+		{"未公開（サイトの公開状態を指定）", WXRSite{Status: "publish"}, false, "draft"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			posts := []*models.BlogPost{{Title: "記事", Published: tt.published}}
+			if err := WriteWXR(&b, tt.site, posts); err != nil {
+				t.Fatalf("WriteWXR() error = %v", err)
+			}
+			want := "<wp:status><![CDATA[" + tt.want + "]]></wp:status>"
+			if !strings.Contains(b.String(), want) {
+				t.Errorf("WXR should contain %s", want)
+			}
+		})
+	}
+}
+
+func TestWXRSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Hello World", "hello-world"},
+		{"認知症", "%e8%aa%8d%e7%9f%a5%e7%97%87"},
+		{" a/b.c ", "a-b-c"},
+		{"!!", ""},
+	}
+	for _, tt := range tests {
+		if got := wxrSlug(tt.name); got != tt.want {
+			t.Errorf("wxrSlug(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
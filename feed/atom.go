@@ -0,0 +1,155 @@
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// ErrMissingFeedID はAtomフィードのidに使用するURL（ChannelのFeedURLかLink）がないことを表します
+var ErrMissingFeedID = errors.New("AtomフィードのidにはFeedURLかLinkが必要です")
+
+// WriteAtom は記事の一覧をAtom 1.0形式で書き出します。
+// 記事の要約にはSummary、本文にはContentを使用し、FirstImageはenclosureのリンクとmedia:thumbnail、
+// カテゴリとタグはcategoryとして出力します。
+// 更新日時が不明な記事はフィードの最終更新日時を使用し、フィードの最終更新日時も不明な場合は現在時刻を使用します。
+// idは必須のため、ChannelにFeedURLもLinkもない場合はErrMissingFeedIDを返します。
+func WriteAtom(w io.Writer, ch Channel, posts []*models.BlogPost) error {
+	id := firstNonEmpty(ch.FeedURL, ch.Link)
+	if id == "" {
+		return fmt.Errorf("Atomの書き込みに失敗しました: %w", ErrMissingFeedID)
+	}
+	updated := ch.updated(posts)
+	if updated.IsZero() {
+		// updatedは必須のため、日時のわかる記事がない場合は現在時刻を使用する
+		updated = time.Now()
+	}
+
+	feed := atomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		MediaNS:  "http://search.yahoo.com/mrss/",
+		Lang:     ch.Language,
+		ID:       id,
+		Title:    ch.Title,
+		Subtitle: ch.Description,
+		Updated:  atomDate(updated),
+		Icon:     ch.Image,
+	}
+	if ch.Link != "" {
+		feed.Links = append(feed.Links, atomLink{Rel: "alternate", Type: "text/html", Href: ch.Link})
+	}
+	if ch.FeedURL != "" {
+		feed.Links = append(feed.Links, atomLink{Rel: "self", Type: "application/atom+xml", Href: ch.FeedURL})
+	}
+	if ch.Author != "" {
+		feed.Author = &atomPerson{Name: ch.Author}
+	}
+
+	for _, post := range posts {
+		entryUpdated := postUpdated(post)
+		if entryUpdated.IsZero() {
+			entryUpdated = updated
+		}
+		entry := atomEntry{
+			ID:        postID(post),
+			Title:     post.Title,
+			Published: atomDate(post.CreatedAt),
+			Updated:   atomDate(entryUpdated),
+		}
+		if entry.ID == "" {
+			// idは必須のため、URLがない場合はフィード内での連番を使用する
+			entry.ID = fmt.Sprintf("%s#%d", id, len(feed.Entries)+1)
+		}
+		if post.URL != "" {
+			entry.Links = append(entry.Links, atomLink{Rel: "alternate", Type: "text/html", Href: post.URL})
+		}
+		if post.FirstImage != "" {
+			entry.Links = append(entry.Links, atomLink{Rel: "enclosure", Type: imageType(post.FirstImage), Href: post.FirstImage})
+			entry.Thumbnail = &mediaThumbnail{URL: post.FirstImage}
+		}
+		if post.Author != "" {
+			entry.Author = &atomPerson{Name: post.Author}
+		}
+		if post.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: post.Summary}
+		}
+		if post.Content != "" {
+			entry.Content = &atomText{Type: "html", Value: post.Content}
+		}
+		for _, name := range postCategories(post) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: name})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return writeXML(w, feed, "Atom")
+}
+
+// atomDate はRFC 3339形式の日時を返します（ゼロ値の場合は空文字列）
+func atomDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// firstNonEmpty は最初の空でない文字列を返します
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	MediaNS  string      `xml:"xmlns:media,attr"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Icon     string      `xml:"icon,omitempty"`
+	Links    []atomLink  `xml:"link"`
+	Author   *atomPerson `xml:"author,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string          `xml:"id"`
+	Title      string          `xml:"title"`
+	Links      []atomLink      `xml:"link"`
+	Published  string          `xml:"published,omitempty"`
+	Updated    string          `xml:"updated"`
+	Author     *atomPerson     `xml:"author,omitempty"`
+	Summary    *atomText       `xml:"summary,omitempty"`
+	Content    *atomText       `xml:"content,omitempty"`
+	Categories []atomCategory  `xml:"category"`
+	Thumbnail  *mediaThumbnail `xml:"media:thumbnail,omitempty"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
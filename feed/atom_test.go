@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestWriteAtom(t *testing.T) {
+	ch := Channel{
+		Title:    "アルツフルデイズ",
+		Link:     "https://ameblo.jp/macb2b37/",
+		FeedURL:  "https://example.com/atom.xml",
+		Language: "ja",
+		Author:   "まっく",
+	}
+	out := render(t, WriteAtom, ch, testPosts())
+	assertWellFormedXML(t, out)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"名前空間と言語", `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/" xml:lang="ja">`},
+		{"フィードのID", `<id>https://example.com/atom.xml</id>`},
+		{"URLのない記事のID", `<id>https://example.com/atom.xml#2</id>`},
+		{"フィードの更新日時", `<updated>2025-04-14T08:00:00+09:00</updated>`},
+		{"selfリンク", `<link rel="self" type="application/atom+xml" href="https://example.com/atom.xml"></link>`},
+		{"記事のリンク", `<link rel="alternate" type="text/html" href="https://ameblo.jp/macb2b37/entry-12887862927.html"></link>`},
+		{"エンクロージャー", `<link rel="enclosure" type="image/png" href="https://stat.ameba.jp/user_images/a/o1.png"></link>`},
+		{"公開日時", `<published>2025-04-13T18:18:05+09:00</published>`},
+		{"要約", `<summary type="text">要約</summary>`},
+		{"本文", `<content type="html">&lt;p&gt;本文&amp;amp;続き`},
+		{"カテゴリ", `<category term="ブログ"></category>`},
+		{"タグ", `<category term="認知症"></category>`},
+		{"サムネイル", `<media:thumbnail url="https://stat.ameba.jp/user_images/a/o1.png"></media:thumbnail>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output does not contain %q\n%s", tt.want, out)
+			}
+		})
+	}
+
+	// 日時が不明な記事はフィードの更新日時を使用する
+	if n := strings.Count(out, "<updated>2025-04-14T08:00:00+09:00</updated>"); n != 3 {
+		t.Errorf("updated count = %d, want 3\n%s", n, out)
+	}
+}
+
+func TestWriteAtomID(t *testing.T) {
+	// FeedURLがなければLinkをフィードのIDに使用する
+	out := render(t, WriteAtom, Channel{Title: "t", Link: "https://example.com/"}, testPosts())
+	for _, want := range []string{"<id>https://example.com/</id>", "<id>https://example.com/#2</id>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<id></id>") {
+		t.Errorf("output contains empty id\n%s", out)
+	}
+
+	var b strings.Builder
+	if err := WriteAtom(&b, Channel{Title: "t"}, testPosts()); !errors.Is(err, ErrMissingFeedID) {
+		t.Errorf("error = %v, want %v", err, ErrMissingFeedID)
+	}
+	if b.Len() != 0 {
+		t.Errorf("output = %q, want empty", b.String())
+	}
+}
+
+func TestWriteAtomUpdatedFallback(t *testing.T) {
+	// 日時のわかる記事がなく、Channel.Updatedも未設定の場合は現在時刻を使用する
+	posts := []*models.BlogPost{{Title: "日時なし", URL: "https://example.com/1"}}
+	before := time.Now().Truncate(time.Second)
+	out := render(t, WriteAtom, Channel{Title: "t", Link: "https://example.com/"}, posts)
+	after := time.Now()
+	assertWellFormedXML(t, out)
+
+	if strings.Contains(out, "<updated></updated>") {
+		t.Fatalf("output contains empty updated\n%s", out)
+	}
+	if n := strings.Count(out, "<updated>"); n != 2 {
+		t.Fatalf("updated count = %d, want 2\n%s", n, out)
+	}
+	start := strings.Index(out, "<updated>") + len("<updated>")
+	end := strings.Index(out, "</updated>")
+	updated, err := time.Parse(time.RFC3339, out[start:end])
+	if err != nil {
+		t.Fatalf("updated = %q: %v", out[start:end], err)
+	}
+	if updated.Before(before) || updated.After(after) {
+		t.Errorf("updated = %v, want between %v and %v", updated, before, after)
+	}
+}
@@ -0,0 +1,83 @@
+package feed
+
+import (
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// Channel はフィード全体のメタデータです。
+type Channel struct {
+	Title       string    // フィード（ブログ）のタイトル
+	Link        string    // ブログのトップページのURL
+	FeedURL     string    // フィード自身のURL
+	Description string    // ブログの説明
+	Language    string    // 言語（ja など）
+	Author      string    // 著者名
+	Image       string    // ブログのアイコン・ロゴ画像のURL
+	Updated     time.Time // 最終更新日時（ゼロ値の場合は記事の最新の日時）
+}
+
+// updated はフィードの最終更新日時を返します。
+// Channel.Updatedが未設定の場合は記事の作成・更新日時の最新のものを使用します。
+func (ch Channel) updated(posts []*models.BlogPost) time.Time {
+	if !ch.Updated.IsZero() {
+		return ch.Updated
+	}
+	var latest time.Time
+	for _, post := range posts {
+		if t := postUpdated(post); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// postUpdated は記事の更新日時（なければ作成日時）を返します
+func postUpdated(post *models.BlogPost) time.Time {
+	if !post.UpdatedAt.IsZero() {
+		return post.UpdatedAt
+	}
+	return post.CreatedAt
+}
+
+// postCategories は記事のカテゴリとタグを重複なく連結します
+func postCategories(post *models.BlogPost) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, list := range [][]string{post.Categories, post.Tags} {
+		for _, name := range list {
+			name = strings.TrimSpace(name)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// postID は記事を一意に識別するIDを返します（パーマリンク、なければ正規URL）
+func postID(post *models.BlogPost) string {
+	if post.URL != "" {
+		return post.URL
+	}
+	return post.CanonicalURL
+}
+
+// imageType は画像URLの拡張子からMIMEタイプを推定します（不明な場合はimage/jpeg）
+func imageType(imageURL string) string {
+	p := imageURL
+	if u, err := url.Parse(imageURL); err == nil {
+		p = u.Path
+	}
+	if t := mime.TypeByExtension(strings.ToLower(path.Ext(p))); strings.HasPrefix(t, "image/") {
+		return t
+	}
+	return "image/jpeg"
+}
@@ -0,0 +1,136 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+var jst = time.FixedZone("JST", 9*3600)
+
+// testPosts はフィードのテストで使用する記事です
+func testPosts() []*models.BlogPost {
+	return []*models.BlogPost{
+		{
+			Title:      "『ルーティーン』",
+			Author:     "まっく",
+			Content:    `<p>本文&amp;続き<img src="https://stat.ameba.jp/user_images/a/o1.png"></p>`,
+			Summary:    "要約",
+			Categories: []string{"ブログ"},
+			Tags:       []string{"認知症", "ブログ"},
+			CreatedAt:  time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			UpdatedAt:  time.Date(2025, 4, 14, 8, 0, 0, 0, jst),
+			URL:        "https://ameblo.jp/macb2b37/entry-12887862927.html",
+			FirstImage: "https://stat.ameba.jp/user_images/a/o1.png",
+		},
+		{
+			Title:   "二つ目",
+			Content: "<p>本文</p>",
+		},
+	}
+}
+
+// assertWellFormedXML は整形式のXMLであることを確認します
+func assertWellFormedXML(t *testing.T, out string) {
+	t.Helper()
+	dec := xml.NewDecoder(strings.NewReader(out))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v\n%s", err, out)
+		}
+	}
+}
+
+func TestChannelUpdated(t *testing.T) {
+	fixed := time.Date(2025, 5, 1, 0, 0, 0, 0, jst)
+	tests := []struct {
+		name  string
+		ch    Channel
+		posts []*models.BlogPost
+		want  time.Time
+	}{
+		{"指定あり", Channel{Updated: fixed}, testPosts(), fixed},
+		{"記事の最新の日時", Channel{}, testPosts(), time.Date(2025, 4, 14, 8, 0, 0, 0, jst)},
+		{"記事なし", Channel{}, nil, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ch.updated(tt.posts); !got.Equal(tt.want) {
+				t.Errorf("updated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostCategories(t *testing.T) {
+	post := &models.BlogPost{
+		Categories: []string{"ブログ", " 日記 "},
+		Tags:       []string{"日記", "", "認知症"},
+	}
+	want := []string{"ブログ", "日記", "認知症"}
+	if got := postCategories(post); !reflect.DeepEqual(got, want) {
+		t.Errorf("postCategories() = %v, want %v", got, want)
+	}
+}
+
+func TestImageType(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/a.png", "image/png"},
+		{"https://example.com/a.JPG?width=300", "image/jpeg"},
+		{"https://example.com/a.gif#x", "image/gif"},
+		{"https://example.com/image", "image/jpeg"},
+		{"https://example.com/a.html", "image/jpeg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := imageType(tt.url); got != tt.want {
+				t.Errorf("imageType(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	writers := map[string]func(io.Writer, Channel, []*models.BlogPost) error{
+		"RSS":      WriteRSS,
+		"Atom":     WriteAtom,
+		"JSONFeed": WriteJSONFeed,
+	}
+	for name, write := range writers {
+		t.Run(name, func(t *testing.T) {
+			if err := write(failWriter{}, Channel{Title: "t", Link: "https://example.com/"}, testPosts()); !errors.Is(err, errWrite) {
+				t.Errorf("error = %v, want %v", err, errWrite)
+			}
+		})
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) { return 0, errWrite }
+
+// render はフィードを文字列として書き出します
+func render(t *testing.T, write func(io.Writer, Channel, []*models.BlogPost) error, ch Channel, posts []*models.BlogPost) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := write(&b, ch, posts); err != nil {
+		t.Fatalf("write error = %v", err)
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// JSON Feedのバージョン
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+// ErrMissingItemID はURLのない記事のidに使用するURL（ChannelのLinkかFeedURL）がないことを表します
+var ErrMissingItemID = errors.New("URLのない記事のidにはLinkかFeedURLが必要です")
+
+// WriteJSONFeed は記事の一覧をJSON Feed 1.1形式で書き出します。
+// 記事の要約にはSummary、本文にはContent、画像にはFirstImageを使用し、カテゴリとタグはtagsとして出力します。
+// URLのない記事のidにはChannelのLink（なければFeedURL）に連番を付けたものを使用し、
+// どちらもない場合はErrMissingItemIDを返します。
+func WriteJSONFeed(w io.Writer, ch Channel, posts []*models.BlogPost) error {
+	base := firstNonEmpty(ch.Link, ch.FeedURL)
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       ch.Title,
+		HomePageURL: ch.Link,
+		FeedURL:     ch.FeedURL,
+		Description: ch.Description,
+		Icon:        ch.Image,
+		Language:    ch.Language,
+		Items:       []jsonFeedItem{},
+	}
+	if ch.Author != "" {
+		feed.Authors = []jsonFeedAuthor{{Name: ch.Author}}
+	}
+
+	for _, post := range posts {
+		item := jsonFeedItem{
+			ID:            postID(post),
+			URL:           post.URL,
+			Title:         post.Title,
+			ContentHTML:   post.Content,
+			Summary:       post.Summary,
+			Image:         post.FirstImage,
+			DatePublished: atomDate(post.CreatedAt),
+			DateModified:  atomDate(post.UpdatedAt),
+			Tags:          postCategories(post),
+		}
+		if item.ID == "" {
+			// idは必須のため、URLがない場合はフィード内での連番を使用する
+			if base == "" {
+				return fmt.Errorf("JSON Feedの書き込みに失敗しました: %w", ErrMissingItemID)
+			}
+			item.ID = fmt.Sprintf("%s#%d", base, len(feed.Items)+1)
+		}
+		if post.Author != "" {
+			item.Authors = []jsonFeedAuthor{{Name: post.Author}}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return fmt.Errorf("JSON Feedの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Icon        string           `json:"icon,omitempty"`
+	Language    string           `json:"language,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
@@ -0,0 +1,110 @@
+package feed
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestWriteJSONFeed(t *testing.T) {
+	ch := Channel{
+		Title:   "アルツフルデイズ",
+		Link:    "https://ameblo.jp/macb2b37/",
+		FeedURL: "https://example.com/feed.json",
+		Author:  "まっく",
+	}
+	out := render(t, WriteJSONFeed, ch, testPosts())
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+
+	if got["version"] != "https://jsonfeed.org/version/1.1" {
+		t.Errorf("version = %v", got["version"])
+	}
+	if got["feed_url"] != "https://example.com/feed.json" {
+		t.Errorf("feed_url = %v", got["feed_url"])
+	}
+	items := got["items"].([]any)
+	if len(items) != 2 {
+		t.Fatalf("items = %d, want 2", len(items))
+	}
+
+	tests := []struct {
+		name string
+		item int
+		want map[string]any
+	}{
+		{
+			name: "全項目",
+			item: 0,
+			want: map[string]any{
+				"id":             "https://ameblo.jp/macb2b37/entry-12887862927.html",
+				"url":            "https://ameblo.jp/macb2b37/entry-12887862927.html",
+				"title":          "『ルーティーン』",
+				"content_html":   `<p>本文&amp;続き<img src="https://stat.ameba.jp/user_images/a/o1.png"></p>`,
+				"summary":        "要約",
+				"image":          "https://stat.ameba.jp/user_images/a/o1.png",
+				"date_published": "2025-04-13T18:18:05+09:00",
+				"date_modified":  "2025-04-14T08:00:00+09:00",
+				"authors":        []any{map[string]any{"name": "まっく"}},
+				"tags":           []any{"ブログ", "認知症"},
+			},
+		},
+		{
+			name: "URLと日時なし",
+			item: 1,
+			want: map[string]any{
+				"id":           "https://ameblo.jp/macb2b37/#2",
+				"title":        "二つ目",
+				"content_html": "<p>本文</p>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(items[tt.item], tt.want) {
+				t.Errorf("item = %v, want %v", items[tt.item], tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteJSONFeedItemID(t *testing.T) {
+	posts := []*models.BlogPost{{Title: "URLなし"}}
+
+	// LinkがなければFeedURLに連番を付けてidにする
+	out := render(t, WriteJSONFeed, Channel{Title: "t", FeedURL: "https://example.com/feed.json"}, posts)
+	if want := `"id": "https://example.com/feed.json#1"`; !strings.Contains(out, want) {
+		t.Errorf("output does not contain %q\n%s", want, out)
+	}
+
+	// URLのある記事だけならLinkもFeedURLも不要
+	render(t, WriteJSONFeed, Channel{Title: "t"}, []*models.BlogPost{{Title: "URLあり", URL: "https://example.com/1"}})
+
+	var b strings.Builder
+	if err := WriteJSONFeed(&b, Channel{Title: "t"}, posts); !errors.Is(err, ErrMissingItemID) {
+		t.Errorf("error = %v, want %v", err, ErrMissingItemID)
+	}
+	if b.Len() != 0 {
+		t.Errorf("output = %q, want empty", b.String())
+	}
+}
+
+func TestWriteJSONFeedEmpty(t *testing.T) {
+	out := render(t, WriteJSONFeed, Channel{Title: "空"}, nil)
+	var got struct {
+		Items []any `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Items == nil {
+		t.Errorf("items should be an empty array: %s", out)
+	}
+}
@@ -0,0 +1,150 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// WriteRSS は記事の一覧をRSS 2.0形式で書き出します。
+// 記事の説明にはSummary、本文にはcontent:encodedとしてContentを使用し、
+// FirstImageはenclosureとmedia:thumbnail、カテゴリとタグはcategoryとして出力します。
+func WriteRSS(w io.Writer, ch Channel, posts []*models.BlogPost) error {
+	channel := rssChannel{
+		Title:         ch.Title,
+		Link:          ch.Link,
+		Description:   ch.Description,
+		Language:      ch.Language,
+		LastBuildDate: rssDate(ch.updated(posts)),
+	}
+	if ch.FeedURL != "" {
+		channel.AtomLink = &rssAtomLink{Href: ch.FeedURL, Rel: "self", Type: "application/rss+xml"}
+	}
+	if ch.Image != "" {
+		channel.Image = &rssImage{URL: ch.Image, Title: ch.Title, Link: ch.Link}
+	}
+
+	for _, post := range posts {
+		item := rssItem{
+			Title:       post.Title,
+			Link:        post.URL,
+			Description: post.Summary,
+			PubDate:     rssDate(post.CreatedAt),
+			Categories:  postCategories(post),
+			Creator:     post.Author,
+		}
+		if id := postID(post); id != "" {
+			item.GUID = &rssGUID{IsPermaLink: "true", Value: id}
+		}
+		if post.Content != "" {
+			item.Content = &rssCDATA{Value: post.Content}
+		}
+		if post.FirstImage != "" {
+			item.Enclosure = &rssEnclosure{URL: post.FirstImage, Length: "0", Type: imageType(post.FirstImage)}
+			item.Thumbnail = &mediaThumbnail{URL: post.FirstImage}
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	doc := rssDocument{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		MediaNS:   "http://search.yahoo.com/mrss/",
+		Channel:   channel,
+	}
+	return writeXML(w, doc, "RSS")
+}
+
+// rssDate はRFC 822形式の日時を返します（ゼロ値の場合は空文字列）
+func rssDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+// writeXML はXML宣言を付けて文書を書き出します
+func writeXML(w io.Writer, doc any, format string) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("%sの書き込みに失敗しました: %w", format, err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("%sの書き込みに失敗しました: %w", format, err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("%sの書き込みに失敗しました: %w", format, err)
+	}
+	return nil
+}
+
+type rssDocument struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	MediaNS   string     `xml:"xmlns:media,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Description   string       `xml:"description"`
+	Language      string       `xml:"language,omitempty"`
+	LastBuildDate string       `xml:"lastBuildDate,omitempty"`
+	AtomLink      *rssAtomLink `xml:"atom:link,omitempty"`
+	Image         *rssImage    `xml:"image,omitempty"`
+	Items         []rssItem    `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssItem struct {
+	Title       string          `xml:"title"`
+	Link        string          `xml:"link,omitempty"`
+	Description string          `xml:"description,omitempty"`
+	Content     *rssCDATA       `xml:"content:encoded,omitempty"`
+	Creator     string          `xml:"dc:creator,omitempty"`
+	Categories  []string        `xml:"category"`
+	GUID        *rssGUID        `xml:"guid,omitempty"`
+	PubDate     string          `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure   `xml:"enclosure,omitempty"`
+	Thumbnail   *mediaThumbnail `xml:"media:thumbnail,omitempty"`
+}
+
+type rssCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type mediaThumbnail struct {
+	URL string `xml:"url,attr"`
+}
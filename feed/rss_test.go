@@ -0,0 +1,53 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteRSS(t *testing.T) {
+	ch := Channel{
+		Title:       "アルツフルデイズ",
+		Link:        "https://ameblo.jp/macb2b37/",
+		FeedURL:     "https://example.com/rss.xml",
+		Description: "介護の記録",
+		Language:    "ja",
+	}
+	out := render(t, WriteRSS, ch, testPosts())
+	assertWellFormedXML(t, out)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"XML宣言", `<?xml version="1.0" encoding="UTF-8"?>`},
+		{"名前空間", `xmlns:media="http://search.yahoo.com/mrss/"`},
+		{"selfリンク", `<atom:link href="https://example.com/rss.xml" rel="self" type="application/rss+xml"></atom:link>`},
+		{"最終更新日時", `<lastBuildDate>Mon, 14 Apr 2025 08:00:00 +0900</lastBuildDate>`},
+		{"説明は要約", `<description>要約</description>`},
+		{"本文", `<content:encoded><![CDATA[<p>本文&amp;続き`},
+		{"著者", `<dc:creator>まっく</dc:creator>`},
+		{"カテゴリ", `<category>ブログ</category>`},
+		{"タグ", `<category>認知症</category>`},
+		{"GUID", `<guid isPermaLink="true">https://ameblo.jp/macb2b37/entry-12887862927.html</guid>`},
+		{"公開日時", `<pubDate>Sun, 13 Apr 2025 18:18:05 +0900</pubDate>`},
+		{"エンクロージャー", `<enclosure url="https://stat.ameba.jp/user_images/a/o1.png" length="0" type="image/png"></enclosure>`},
+		{"サムネイル", `<media:thumbnail url="https://stat.ameba.jp/user_images/a/o1.png"></media:thumbnail>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output does not contain %q\n%s", tt.want, out)
+			}
+		})
+	}
+
+	// カテゴリとタグで重複する「ブログ」は1つだけ出力する
+	if n := strings.Count(out, "<category>ブログ</category>"); n != 1 {
+		t.Errorf("category ブログ count = %d, want 1", n)
+	}
+	// URLのない記事にはguidを出力しない
+	if n := strings.Count(out, "<guid"); n != 1 {
+		t.Errorf("guid count = %d, want 1", n)
+	}
+}
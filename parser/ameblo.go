@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// アメブロのページに埋め込まれた初期データの変数名
+const amebloInitDataPrefix = "window.INIT_DATA="
+
+// アメブロの記事URLのパス（/ameba_id/entry-記事ID.html）
+var amebloEntryPathRe = regexp.MustCompile(`/entry-(\d+)\.html`)
+
+// amebloInitData はアメブロのページに埋め込まれたwindow.INIT_DATAを解析して返します。
+// 見つからない場合や解析できない場合はnilを返します。
+func amebloInitData(doc *goquery.Document) map[string]any {
+	var data map[string]any
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		script := s.Text()
+		idx := strings.Index(script, amebloInitDataPrefix)
+		if idx == -1 {
+			return true
+		}
+		// 代入文の後続のJavaScriptは無視してJSON部分のみをデコードする
+		dec := json.NewDecoder(strings.NewReader(script[idx+len(amebloInitDataPrefix):]))
+		if err := dec.Decode(&data); err != nil {
+			data = nil
+		}
+		return false
+	})
+	return data
+}
+
+// amebloEntry はINIT_DATAから表示中の記事情報と所属するブログ情報を取り出します。
+// entryMapには前後の記事が含まれることがあるため、表示中のパスから記事IDを特定します。
+func amebloEntry(data map[string]any) (entry, blog map[string]any) {
+	entries := jsonObject(data, "entryState", "entryMap")
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	if pathname := jsonString(jsonObject(data, "router", "location")["pathname"]); pathname != "" {
+		if matches := amebloEntryPathRe.FindStringSubmatch(pathname); len(matches) > 1 {
+			entry, _ = entries[matches[1]].(map[string]any)
+		}
+	}
+	if entry == nil && len(entries) == 1 {
+		for _, v := range entries {
+			entry, _ = v.(map[string]any)
+		}
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	blogID := jsonNumberString(entry["blog_id"])
+	blog = jsonObject(data, "bloggerState", "blogMap", blogID)
+	return entry, blog
+}
+
+// amebloPermalink はINIT_DATAの記事情報から記事のパーマリンクを組み立てます
+func amebloPermalink(entry, blog map[string]any) string {
+	entryID := jsonNumberString(entry["entry_id"])
+	blogName := jsonString(blog["blog_name"])
+	if entryID == "" || blogName == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://ameblo.jp/%s/entry-%s.html", blogName, entryID)
+}
+
+// jsonNumberString はJSONの数値または文字列を10進数の文字列に変換します
+func jsonNumberString(v any) string {
+	switch t := v.(type) {
+	case float64:
+		return fmt.Sprintf("%.0f", t)
+	case string:
+		return strings.TrimSpace(t)
+	}
+	return ""
+}
@@ -0,0 +1,298 @@
+package parser
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// articleMarkup はプラットフォーム固有の記事のマークアップを表すセレクタの組です。
+// カンマ区切りのセレクタは記述した順に優先して探します。
+type articleMarkup struct {
+	title      string // タイトル
+	subtitle   string // サブタイトル
+	site       string // 記事が掲載されたパブリケーション（サイト）の名前
+	date       string // 公開日時（datetime属性、なければテキスト）
+	categories string // カテゴリ
+	tags       string // タグ
+	author     string // 著者名
+	content    string // 本文
+	image      string // アイキャッチ画像（imgはsrc、metaはcontentを参照する）
+	remove     string // 本文から削除する要素（スター・共有ボタン・広告など）
+	unwrap     string // リンクを外してテキストだけを残す要素（自動リンクなど）
+
+	// meta はscriptに埋め込まれた記事データやclass属性などからメタデータを取り出します（セレクタで見つからない場合に使用する）
+	meta func(doc *goquery.Document) articleMeta
+}
+
+// articleMeta はセレクタ以外の方法で取り出した記事のメタデータです
+type articleMeta struct {
+	postID     string
+	title      string
+	subtitle   string
+	siteName   string
+	author     string
+	date       time.Time
+	updated    time.Time
+	categories []string
+	tags       []string
+	image      string
+	engagement map[string]int // 反応の数（likes・comments・shares）
+}
+
+// metadata はセレクタ以外の方法で取り出した記事のメタデータを返します（取り出し方がない場合はゼロ値）
+func (m articleMarkup) metadata(doc *goquery.Document) articleMeta {
+	if m.meta == nil {
+		return articleMeta{}
+	}
+	return m.meta(doc)
+}
+
+// プラットフォームごとの記事のマークアップ
+var articleMarkups = map[platform]articleMarkup{
+	platformHatena: {
+		title:      "h1.entry-title a.entry-title-link, h1.entry-title",
+		date:       ".entry-header .entry-date time[datetime], .entry-footer-time time[datetime]",
+		categories: ".entry-categories a.entry-category-link",
+		author:     ".entry-footer .author .fn, .entry-footer .author",
+		content:    "div.entry-content",
+		remove: ".hatena-star-container, .hatena-star-add-button, .social-buttons, .entry-footer-html, .entry-footer-modules, " +
+			".customized-footer, .google-afc-user-container, .google-afc-image, .sentry",
+		unwrap: "a.keyword",
+	},
+	platformExcite: {
+		title:   ".POST_HEAD h2",
+		tags:    "#archiveLinks .tagLink a[href*='/tags/'], .POST_TAIL a[href*='/tags/']",
+		content: "div.POST_BODY",
+		// 記事末尾の「by ブログ名 | 日時」、トラックバック、Webプッシュ通知の案内などを削除する
+		remove: "div.POST_TAIL, .TRACKBACK, #trackback_area, .bbs_preview, div[hx-get*='/parts/webpush/'], " +
+			"span[data-ex-id^='form-']",
+		meta: exciteMeta,
+	},
+	platformNote: {
+		title:   "h1.o-noteContentHeader__title, h1.o-noteContentText__title",
+		date:    ".o-noteContentHeader__date time[datetime], .o-noteContentHeader time[datetime]",
+		tags:    ".m-tagList__item a, a.a-tag__label, a[href^='/hashtag/']",
+		author:  ".o-noteContentHeader__name a, .o-noteContentHeader__name",
+		content: ".note-common-styles__textnote-body",
+		image:   ".o-noteEyecatch img, .o-noteContentHeader__eyecatch img",
+		remove: ".paywall-line, .o-noteContentText__paywall, .o-noteContentText__purchase, .m-noteBodyPaywall, " +
+			".o-noteLikeV3, .m-noteLikeButton, .o-noteActionButtons, .o-noteSupport, .o-creatorFollow",
+		meta: noteScriptMeta,
+	},
+	platformBlogger: {
+		title:   "h3.post-title, h1.post-title, .post-title.entry-title",
+		date:    ".post-timestamp abbr.published[title], .post-timestamp time.published[datetime], abbr.published[title], time.published[datetime]",
+		tags:    ".post-labels a",
+		author:  ".post-author .fn, .post-author [itemprop='name']",
+		content: ".post-body",
+		remove: ".post-share-buttons, .share-button, .sharing, .post-footer, .blog-pager, .widget.Navbar, .widget.Attribution, " +
+			".widget-item-control, .item-control, .quickedit",
+		meta: bloggerMeta,
+	},
+	// 日付見出しと記事末尾の投稿時刻が別の要素にあるサービスは、dateを指定せずmetaで組み立てる
+	platformSeesaa: {
+		title:      "h3.title, .article-title",
+		categories: ".posted a[href*='/category/'], .article-info a[href*='/category/']",
+		tags:       ".posted a[href*='/tag/'], .article-info a[href*='/tag/']",
+		content:    "div.text, .article-body",
+		remove:     ".seesaa-ad, .seesaa_ad, [id^='seesaa_ad'], .ad_seesaa",
+		meta:       footerMeta("h2.date, .article-date", ".posted, .article-info", regexp.MustCompile(`posted by\s*(.+?)\s+at\s`)),
+	},
+	platformJugem: {
+		title:      ".entry_title",
+		categories: ".entry_state a[href*='cid=']",
+		content:    "div.entry",
+		remove:     ".entry_title, .entry_date, .entry_state, .entry_pr, .jugem_ad, #jugem_pr",
+		meta:       footerMeta(".entry_date", ".entry_state", regexp.MustCompile(`(?i)posted by\s*([^|｜]+?)\s*(?:[|｜]|$)`)),
+	},
+	platformGoo: {
+		title:      ".entry-top-info-title",
+		categories: ".entry-top-info-category a, .entry-bottom-info-category a",
+		content:    ".entry-body-text, .entry-body",
+		remove:     ".entry-sns, .entry-bottom-ad, .goo-ad, .gooad",
+		meta:       footerMeta(".entry-top-info-time", ".entry-bottom-info-time", nil),
+	},
+	platformYahoo: {
+		title:      ".entryTitle h3, .entryTitle",
+		categories: ".entryCategory a",
+		content:    ".entryTd, .entryBody",
+		remove:     ".entryFooter, .niceButton, .ycAd, .yadsOverlay",
+		meta:       footerMeta(".entryDate", ".entryDate", nil),
+	},
+	// コードブロック・数式は本文のクリーニングで言語・TeXを残した形に変換する
+	platformQiita: {
+		title:   "h1[itemprop='headline'], .it-Header_title, article h1",
+		tags:    "a[href^='/tags/'], a[href^='https://qiita.com/tags/']",
+		content: "#personal-public-article-body, .it-MdContent, .mdContent-inner",
+		meta:    qiitaMeta,
+	},
+	platformZenn: {
+		title:   "h1[class*='ArticleHeader_title'], article h1",
+		tags:    "a[href^='/topics/']",
+		content: "div.znc",
+		meta:    zennMeta,
+	},
+	platformMedium: {
+		title:    "h1[data-testid='storyTitle'], h1.pw-post-title",
+		subtitle: ".pw-subtitle-paragraph, h2[data-testid='storySubtitle']",
+		site:     "a[data-testid='publicationName'] p, a[data-testid='publicationName']",
+		tags:     "a[href*='/tag/']",
+		author:   "a[data-testid='authorName'], .pw-author-name",
+		content:  "section[data-field='body'], article",
+		remove:   ".speechify-ignore, [data-testid='paywall'], [data-testid='audioPlayButton'], [data-testid='headerClapButton']",
+		meta:     mediumMeta,
+	},
+	platformSubstack: {
+		title:    "h1.post-title",
+		subtitle: "h3.subtitle",
+		author:   ".byline-names a, .post-header .profile-hover-card-target a",
+		content:  "div.available-content .body.markup, div.body.markup",
+		remove: ".subscription-widget-wrap, .subscription-widget-wrap-editor, .subscription-widget, .subscribe-widget, " +
+			".paywall, .paywall-jump, .button-wrapper[data-attrs*='subscribe'], .captioned-button-wrap, .post-ufi, .share-dialog",
+		meta: substackMeta,
+	},
+	platformWordPress: {
+		title:      "article h1.entry-title, h1.entry-title, h1.wp-block-post-title",
+		date:       "article time.entry-date[datetime], time.published[datetime], .wp-block-post-date time[datetime]",
+		categories: ".cat-links a, .wp-block-post-terms.taxonomy-category a",
+		tags:       ".tags-links a, .wp-block-post-terms.taxonomy-post_tag a, a[rel='tag']",
+		author:     ".entry-meta .author .fn, .byline .author a, .wp-block-post-author__name",
+		content:    "div.entry-content, .wp-block-post-content",
+		image:      "img.wp-post-image, .wp-block-post-featured-image img",
+		remove: "#jp-post-flair, .sharedaddy, .sd-sharing-enabled, .sd-block, .jp-relatedposts, #jp-relatedposts, " +
+			".jetpack-likes-widget-wrapper, .sd-like, .wpcnt",
+		meta: wordpressMeta,
+	},
+}
+
+// articlePage は解析中の記事のHTMLドキュメントと、そのプラットフォーム・記事のメタデータです。
+// プラットフォームの判定とscriptに埋め込まれた記事データの解析は重いため、Parseごとに一度だけ行います。
+type articlePage struct {
+	doc       *goquery.Document
+	platform  platform
+	markup    articleMarkup
+	hasMarkup bool        // プラットフォーム固有の記事のマークアップがあるか
+	meta      articleMeta // セレクタ以外の方法で取り出した記事のメタデータ
+}
+
+// newArticlePage はHTMLドキュメントのプラットフォームを判定し、記事のメタデータを取り出します
+func newArticlePage(doc *goquery.Document) *articlePage {
+	pf := detectPlatform(doc)
+	markup, ok := articleMarkups[pf]
+	page := &articlePage{doc: doc, platform: pf, markup: markup, hasMarkup: ok}
+	if ok {
+		page.meta = markup.metadata(doc)
+	}
+	return page
+}
+
+// markupText はセレクタに一致する最初の空でない要素のテキストを返します
+func markupText(doc *goquery.Document, selector string) string {
+	for _, sel := range strings.Split(selector, ",") {
+		if sel = strings.TrimSpace(sel); sel == "" {
+			continue
+		}
+		if text := strings.Join(strings.Fields(doc.Find(sel).First().Text()), " "); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// markupTexts はセレクタに一致するすべての要素のテキストを重複なく返します
+func markupTexts(doc *goquery.Document, selector string, clean func(string) string) []string {
+	var texts []string
+	if selector == "" {
+		return nil
+	}
+	doc.Find(selector).Each(func(i int, s *goquery.Selection) {
+		if text := clean(s.Text()); text != "" && !containsString(texts, text) {
+			texts = append(texts, text)
+		}
+	})
+	return texts
+}
+
+// markupDate はセレクタに一致する要素から公開日時を取り出します。
+// datetime属性、title属性（abbr要素）、要素のテキスト、テキスト中の日付の順に試します。
+func markupDate(doc *goquery.Document, selector string) (time.Time, bool) {
+	if selector == "" {
+		return time.Time{}, false
+	}
+	var found time.Time
+	doc.Find(selector).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		for _, attr := range []string{"datetime", "title"} {
+			if dt := strings.TrimSpace(s.AttrOr(attr, "")); dt != "" {
+				if t, err := parseDateString(dt); err == nil {
+					found = t
+					return false
+				}
+			}
+		}
+		text := strings.TrimSpace(s.Text())
+		if t, err := parseDateString(text); err == nil {
+			found = t
+			return false
+		}
+		if t, ok := extractDateFromText(text); ok {
+			found = t
+			return false
+		}
+		return true
+	})
+	return found, !found.IsZero()
+}
+
+// footerMeta は日付見出しと、記事末尾の「posted by 著者 at 12:34」のような表記から
+// 公開日時（日本時間）と著者名を取り出す関数を返します（authorReがnilの場合は著者名を取り出さない）
+func footerMeta(dateSelector, footerSelector string, authorRe *regexp.Regexp) func(doc *goquery.Document) articleMeta {
+	return func(doc *goquery.Document) articleMeta {
+		var meta articleMeta
+		date, footer := markupText(doc, dateSelector), markupText(doc, footerSelector)
+		meta.date = parseDateWithTime(date, date+" "+footer, jstLocation)
+		if authorRe != nil {
+			if matches := authorRe.FindStringSubmatch(footer); len(matches) > 1 {
+				meta.author = strings.TrimSpace(matches[1])
+			}
+		}
+		return meta
+	}
+}
+
+// markupImage はセレクタに一致する最初の要素から画像のURLを取り出します
+func markupImage(doc *goquery.Document, selector string) string {
+	for _, sel := range strings.Split(selector, ",") {
+		if sel = strings.TrimSpace(sel); sel == "" {
+			continue
+		}
+		s := doc.Find(sel).First()
+		for _, attr := range []string{"data-src", "src", "content"} {
+			if v := strings.TrimSpace(s.AttrOr(attr, "")); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// extractFeaturedImage はプラットフォーム固有のアイキャッチ画像のURLを返します。
+// 見つからない場合は空文字列を返し、本文やOGPの画像を使用します。
+func extractFeaturedImage(page *articlePage, base *url.URL) string {
+	if !page.hasMarkup {
+		return ""
+	}
+	image := markupImage(page.doc, page.markup.image)
+	if image == "" {
+		image = page.meta.image
+	}
+	return normalizeImageURL(resolveURL(base, image))
+}
+
+// extractPostID はブログサービスでの記事IDを返します（取り出せない場合は空文字列）
+func extractPostID(page *articlePage) string {
+	return page.meta.postID
+}
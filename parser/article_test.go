@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const testHatenaEntry = `<!DOCTYPE html>
+<html lang="ja" data-admin-domain="//blog.hatena.ne.jp" data-author="hatenauser" data-blog-name="はてなの日記" data-page="entry">
+<head>
+<title>散歩の記録 - はてなの日記</title>
+<meta property="og:title" content="散歩の記録 - はてなの日記">
+<meta property="og:site_name" content="はてなの日記">
+<link rel="canonical" href="https://example.hatenablog.com/entry/2025/04/13/181805">
+<link rel="stylesheet" href="https://cdn.blog.st-hatena.com/css/blog.css">
+</head>
+<body>
+<article class="entry hentry">
+<div class="entry-inner">
+<header class="entry-header">
+<div class="date entry-date first"><a href="/archive/2025/04/13"><time datetime="2025-04-13T09:18:05Z" title="2025-04-13T09:18:05Z"><span class="date-year">2025</span>-<span class="date-month">04</span>-<span class="date-day">13</span></time></a></div>
+<div class="entry-categories categories">
+<a href="/archive/category/日記" class="entry-category-link category-日記">日記</a>
+<a href="/archive/category/散歩" class="entry-category-link category-散歩">散歩</a>
+</div>
+<h1 class="entry-title"><a href="https://example.hatenablog.com/entry/2025/04/13/181805" class="entry-title-link bookmark">散歩の記録</a></h1>
+</header>
+<div class="entry-content hatenablog-entry">
+<p>今日は<a class="keyword" href="https://d.hatena.ne.jp/keyword/%E6%9C%88%E5%B1%B1">月山</a>の見える公園まで散歩した。</p>
+<div class="google-afc-user-container"><span class="ad-label">広告</span></div>
+<p>帰りにパン屋に寄った。</p>
+<div class="hatena-module hatena-module-related-entries"><div class="hatena-module-title">関連記事</div>
+<ul class="related-entries hatena-urllist"><li><a href="https://example.hatenablog.com/entry/2025/04/01/100000" class="urllist-title-link">桜の記録</a></li></ul></div>
+</div>
+<footer class="entry-footer">
+<p class="entry-footer-section"><span class="author vcard"><span class="fn" data-user-name="hatenauser"><a href="/about">hatenauser</a></span></span>
+<span class="entry-footer-time"><a href="#"><time datetime="2025-04-13T09:18:05Z">18:18</time></a></span></p>
+<div class="hatena-star-container" data-hatena-star-container><a class="hatena-star-add-button">スターを付ける</a></div>
+<div class="social-buttons"><a href="#">はてなブックマーク</a></div>
+<div class="comment-box">
+<ul class="comment">
+<li class="entry-comment">
+<p class="comment-user-name"><a class="hatena-id-link" href="https://profile.hatena.ne.jp/reader/">reader</a></p>
+<p class="comment-content">いい景色ですね</p>
+<p class="comment-metadata"><time datetime="2025-04-14T00:00:00Z">4日前</time></p>
+</li>
+</ul>
+</div>
+</footer>
+</div>
+</article>
+<div class="pager pager-permalink permalink">
+<span class="pager-prev"><a href="https://example.hatenablog.com/entry/2025/04/14/090000" rel="prev">« 翌日の出来事</a></span>
+<span class="pager-next"><a href="https://example.hatenablog.com/entry/2025/04/12/090000" rel="next">前日の出来事 »</a></span>
+</div>
+</body>
+</html>`
+
+func TestParseHatenaEntry(t *testing.T) {
+	post, err := New().Parse(context.Background(), strings.NewReader(testHatenaEntry))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	checks := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"Title", post.Title, "散歩の記録"},
+		{"Author", post.Author, "hatenauser"},
+		{"CreatedAt", post.CreatedAt, time.Date(2025, 4, 13, 9, 18, 5, 0, time.UTC)},
+		{"Categories", post.Categories, []string{"日記", "散歩"}},
+		{"SiteName", post.SiteName, "はてなの日記"},
+		{"PrevPost", post.PrevPost.URL, "https://example.hatenablog.com/entry/2025/04/12/090000"},
+		{"NextPost", post.NextPost.URL, "https://example.hatenablog.com/entry/2025/04/14/090000"},
+		{"RelatedPosts", len(post.RelatedPosts), 1},
+		{"Comments", len(post.Comments), 1},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s = %#v, want %#v", c.name, c.got, c.want)
+		}
+	}
+	if len(post.Comments) == 1 && (post.Comments[0].Author != "reader" || post.Comments[0].Body != "いい景色ですね") {
+		t.Errorf("Comments[0] = %+v", post.Comments[0])
+	}
+
+	for _, want := range []string{"今日は月山の見える公園まで散歩した。", "帰りにパン屋に寄った。"} {
+		if !strings.Contains(post.Content, want) {
+			t.Errorf("Content does not contain %q: %s", want, post.Content)
+		}
+	}
+	for _, unwanted := range []string{"関連記事", "桜の記録", "広告", "スターを付ける", "はてなブックマーク", "keyword"} {
+		if strings.Contains(post.Content, unwanted) {
+			t.Errorf("Content contains %q: %s", unwanted, post.Content)
+		}
+	}
+}
+
+func TestParseExtractsArticleMetaOnce(t *testing.T) {
+	// 記事データの解析はParseごとに一度だけ行う
+	markup := articleMarkups[platformHatena]
+	t.Cleanup(func() { articleMarkups[platformHatena] = markup })
+	calls := 0
+	counted := markup
+	counted.meta = func(doc *goquery.Document) articleMeta {
+		calls++
+		return articleMeta{subtitle: "副題"}
+	}
+	articleMarkups[platformHatena] = counted
+
+	post, err := New().Parse(context.Background(), strings.NewReader(testHatenaEntry))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("meta calls = %d, want 1", calls)
+	}
+	if post.Subtitle != "副題" {
+		t.Errorf("Subtitle = %q, want 副題", post.Subtitle)
+	}
+}
+
+func TestMarkupDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		selector string
+		want     time.Time
+	}{
+		{"datetime属性", `<time datetime="2025-04-13T09:18:05Z">昨日</time>`, "time", time.Date(2025, 4, 13, 9, 18, 5, 0, time.UTC)},
+		{"テキスト", `<span class="d">2025-04-13</span>`, ".d", time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC)},
+		{"テキスト中の日付", `<span class="d">投稿日：2025年4月13日(日) 18:18</span>`, ".d", time.Date(2025, 4, 13, 18, 18, 0, 0, time.UTC)},
+		{"2つ目の要素", `<span class="d">不明</span><span class="d">2025/04/13</span>`, ".d", time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC)},
+		{"見つからない", `<p>本文</p>`, ".d", time.Time{}},
+		{"セレクタなし", `<p>本文</p>`, "", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, found := markupDate(doc, tt.selector)
+			if !got.Equal(tt.want) || found != !tt.want.IsZero() {
+				t.Errorf("markupDate() = %v, %v, want %v", got, found, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkupText(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<h1 class="a"> </h1><h1 class="b">
+	タイトル  です</h1>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := markupText(doc, "h1.a, h1.b"); got != "タイトル です" {
+		t.Errorf("markupText() = %q", got)
+	}
+	if got := markupText(doc, ".none"); got != "" {
+		t.Errorf("markupText() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"strings"
+)
+
+// 著者名を表す一般的なマークアップ（記述した順に優先する）
+var authorSelectors = []string{
+	".author.vcard .fn",
+	"a[rel='author']",
+	".byline .author",
+	".entry-author-name",
+	".post-author-name",
+}
+
+// extractAuthor はHTMLドキュメントから記事の著者名を抽出します。
+// 以下の優先順位で抽出を試みます：
+// 1. プラットフォーム固有のマークアップ（articleMarkups）とscriptに埋め込まれた記事データ
+// 2. JSON-LDのauthor.name
+// 3. meta[name='author']
+// 4. 一般的なマークアップ（.author.vcard .fn、a[rel='author'] など）
+func extractAuthor(page *articlePage) string {
+	doc := page.doc
+	if doc == nil {
+		return ""
+	}
+
+	// 1. プラットフォーム固有のマークアップ
+	if page.hasMarkup {
+		if author := markupText(doc, page.markup.author); author != "" {
+			return author
+		}
+		if author := page.meta.author; author != "" {
+			return author
+		}
+	}
+
+	// 2. JSON-LDのauthor
+	if article := jsonLDArticle(doc); article != nil {
+		if author := jsonLDName(article["author"]); author != "" {
+			return author
+		}
+	}
+
+	// 3. meta[name='author']
+	if author := strings.TrimSpace(doc.Find("meta[name='author']").First().AttrOr("content", "")); author != "" {
+		return author
+	}
+
+	// 4. 一般的なマークアップ
+	return markupText(doc, strings.Join(authorSelectors, ", "))
+}
+
+// jsonLDName はJSON-LDのPerson・Organizationなどから名前を取り出します（配列の場合は最初の要素）
+func jsonLDName(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case map[string]any:
+		if name, ok := t["name"].(string); ok {
+			return strings.TrimSpace(name)
+		}
+	case []any:
+		for _, item := range t {
+			if name := jsonLDName(item); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
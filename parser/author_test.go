@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestExtractAuthor(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "JSON-LD",
+			html: `<script type="application/ld+json">{"@type":"BlogPosting","author":{"@type":"Person","name":"ワフウフ"}}</script><meta name="author" content="macb2b37">`,
+			want: "ワフウフ",
+		},
+		{
+			name: "JSON-LDの著者が配列",
+			html: `<script type="application/ld+json">{"@type":"Article","author":[{"@type":"Person","url":"https://example.com/"},{"@type":"Person","name":"二人目"}]}</script>`,
+			want: "二人目",
+		},
+		{
+			name: "metaタグ",
+			html: `<meta name="author" content="kapparinrin">`,
+			want: "kapparinrin",
+		},
+		{
+			name: "vcard",
+			html: `<span class="author vcard"><span class="fn"> 山田 太郎 </span></span>`,
+			want: "山田 太郎",
+		},
+		{
+			name: "rel=author",
+			html: `<a rel="author" href="/about">管理人</a>`,
+			want: "管理人",
+		},
+		{
+			name: "はてなブログ",
+			html: `<html data-admin-domain="//blog.hatena.ne.jp"><body><footer class="entry-footer"><span class="author vcard"><span class="fn">hatenauser</span></span></footer><meta name="author" content="other"></body></html>`,
+			want: "hatenauser",
+		},
+		{
+			name: "見つからない",
+			html: `<p>本文</p>`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := extractAuthor(newArticlePage(doc)); got != tt.want {
+				t.Errorf("extractAuthor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := extractAuthor(newArticlePage(nil)); got != "" {
+		t.Errorf("extractAuthor(newArticlePage(nil)) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,25 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// bloggerMeta はBloggerの記事IDと、日付見出し・投稿時刻のテキストから公開日時を取り出します。
+// datetime属性がないテンプレートでは、ブログの言語設定に応じた表記（2025年4月13日日曜日、Sunday, April 13, 2025）になります。
+// 表記のタイムゾーンはブログごとの設定で記事からは分からないため、UTCとして扱います。
+func bloggerMeta(doc *goquery.Document) articleMeta {
+	meta := articleMeta{
+		postID: strings.TrimSpace(doc.Find("meta[itemprop='postId']").First().AttrOr("content", "")),
+	}
+
+	post := doc.Find(".post").First()
+	header := strings.TrimSpace(post.Closest(".date-outer").Find(".date-header").First().Text())
+	if header == "" {
+		header = strings.TrimSpace(doc.Find(".date-header").First().Text())
+	}
+	meta.date = parseDateWithTime(header, strings.TrimSpace(doc.Find(".post-timestamp").First().Text()), time.UTC)
+	return meta
+}
@@ -10,16 +10,27 @@ import (
 )
 
 // extractCategories はHTMLドキュメントからカテゴリを抽出します。
-// 以下の優先順位で抽出を試みます：
+// プラットフォーム固有のマークアップ（articleMarkups）やclass属性などの記事データで見つかった場合はそれを優先し、
+// 見つからない場合は以下の優先順位で抽出を試みます：
 // 1. 一般的なブログプラットフォームのセレクタ
 // 2. ld_blog_varsのarticles[0].categories
 // 3. meta[property='article:section']
 // 4. .category クラスを持つ要素
-func extractCategories(doc *goquery.Document) ([]string, error) {
+func extractCategories(page *articlePage) ([]string, error) {
+	doc := page.doc
 	if doc == nil {
 		return nil, errors.New("ドキュメントがnilです")
 	}
 
+	if page.hasMarkup {
+		if categories := markupTexts(doc, page.markup.categories, strings.TrimSpace); len(categories) > 0 {
+			return categories, nil
+		}
+		if categories := page.meta.categories; len(categories) > 0 {
+			return categories, nil
+		}
+	}
+
 	var categories []string
 
 	// 1. 一般的なブログプラットフォームのセレクタから抽出
@@ -39,8 +50,7 @@ func extractCategories(doc *goquery.Document) ([]string, error) {
 		"dd.article-category2",                     //livedoorのカテゴリ
 
 		// エキサイトブログ固有
-		".POST_TAIL .TIME a[href*=\"/i\"]", // エキサイトブログのカテゴリリンク
-		".articleTheme",                    // 別のパターン
+		".articleTheme", // 別のパターン
 		"a[rel='category']",
 		".category a",
 		".cat-links a",
@@ -62,7 +62,7 @@ func TestExtractCategories_Selectors(t *testing.T) {
 	if err != nil {
 		t.Fatalf("goquery.NewDocumentFromReader error: %v", err)
 	}
-	cats, err := extractCategories(doc)
+	cats, err := extractCategories(newArticlePage(doc))
 	if err != nil {
 		t.Fatalf("extractCategories error: %v", err)
 	}
@@ -77,7 +77,7 @@ func TestExtractCategories_LdBlogVars(t *testing.T) {
 	if err != nil {
 		t.Fatalf("goquery.NewDocumentFromReader error: %v", err)
 	}
-	cats, err := extractCategories(doc)
+	cats, err := extractCategories(newArticlePage(doc))
 	if err != nil {
 		t.Fatalf("extractCategories error: %v", err)
 	}
@@ -92,7 +92,7 @@ func TestExtractCategories_MetaSection(t *testing.T) {
 	if err != nil {
 		t.Fatalf("goquery.NewDocumentFromReader error: %v", err)
 	}
-	cats, err := extractCategories(doc)
+	cats, err := extractCategories(newArticlePage(doc))
 	if err != nil {
 		t.Fatalf("extractCategories error: %v", err)
 	}
@@ -107,7 +107,7 @@ func TestExtractCategories_CategoryClass(t *testing.T) {
 	if err != nil {
 		t.Fatalf("goquery.NewDocumentFromReader error: %v", err)
 	}
-	cats, err := extractCategories(doc)
+	cats, err := extractCategories(newArticlePage(doc))
 	if err != nil {
 		t.Fatalf("extractCategories error: %v", err)
 	}
@@ -117,8 +117,8 @@ func TestExtractCategories_CategoryClass(t *testing.T) {
 }
 
 func TestExtractCategories_NilDoc(t *testing.T) {
-	_, err := extractCategories(nil)
+	_, err := extractCategories(newArticlePage(nil))
 	if err == nil {
-		t.Error("extractCategories(nil) should return error")
+		t.Error("extractCategories(newArticlePage(nil)) should return error")
 	}
 }
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+// metaで宣言された文字コード（<meta charset>、<meta http-equiv="Content-Type">）
+var metaCharsetRe = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?\s*([\w.:-]+)`)
+
+// decodeHTML はHTMLをUTF-8の文字列に変換します。
+// UTF-8として正しい場合はそのまま使用します（保存時にUTF-8に変換されてもmetaの宣言は残るため）。
+// そうでない場合はmetaで宣言された文字コード、宣言がない場合はShift_JIS・EUC-JPを自動判定して変換します。
+func decodeHTML(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	head := data
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	if matches := metaCharsetRe.FindSubmatch(head); matches != nil {
+		if enc, _ := charset.Lookup(string(matches[1])); enc != nil {
+			if decoded, err := enc.NewDecoder().Bytes(data); err == nil {
+				return string(decoded)
+			}
+		}
+	}
+
+	if text, err := decodeJapaneseText(data); err == nil {
+		return text
+	}
+	return string(data)
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestDecodeHTML(t *testing.T) {
+	const body = `<p>月山に思いを馳せる</p>`
+	encode := func(s string, enc *encoding.Encoder) []byte {
+		encoded, err := enc.String(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []byte(encoded)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"UTF-8", []byte(body), body},
+		{"UTF-8 BOM付き", []byte("\ufeff" + body), body},
+		{
+			"metaでShift_JISを宣言",
+			encode(`<meta charset="Shift_JIS">`+body, japanese.ShiftJIS.NewEncoder()),
+			`<meta charset="Shift_JIS">` + body,
+		},
+		{
+			"http-equivでEUC-JPを宣言",
+			encode(`<meta http-equiv="Content-Type" content="text/html; charset=EUC-JP">`+body, japanese.EUCJP.NewEncoder()),
+			`<meta http-equiv="Content-Type" content="text/html; charset=EUC-JP">` + body,
+		},
+		{"宣言のないEUC-JP", encode(body, japanese.EUCJP.NewEncoder()), body},
+		{
+			"UTF-8に変換済みでShift_JISの宣言が残っている",
+			[]byte(`<meta charset="Shift_JIS">` + body),
+			`<meta charset="Shift_JIS">` + body,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeHTML(tt.data); got != tt.want {
+				t.Errorf("decodeHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -20,7 +21,6 @@ var (
 		"div.rss2-title",
 		"a[href*='newresu1.blog.fc2.com']",
 		"div.ad-entry-bottom",
-		"div.POST_TAIL",
 		"hr[style*='191970']",
 	}
 
@@ -49,7 +49,25 @@ var (
 )
 
 // CleanContent はHTMLコンテンツをクリーニングし、HTMLのまま返します。
+// YouTubeやX/Twitterなどの埋め込みメディアはリンクを含むプレースホルダーに変換されます。
+// WithBaseURLで基準URLが指定されている場合は、相対URLを絶対URLに変換します。
+// ブログサービス固有の不要な要素は、本文のHTMLから判定したサービスのものだけを削除します。
 func (p *HTMLParser) CleanContent(content string) (string, error) {
+	return p.cleanContent(content, parseAbsoluteURL(p.baseURL), contentPlatform(content))
+}
+
+// contentPlatform は本文のHTMLからブログサービスを判定します（判定できない場合はplatformUnknown）
+func contentPlatform(content string) platform {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return platformUnknown
+	}
+	return detectPlatform(doc)
+}
+
+// cleanContent は基準URLと記事のブログサービスを指定してHTMLコンテンツをクリーニングします。
+// ブログサービス固有の要素の削除・リンクの解除は、pfのマークアップだけを適用します（他のサービスの汎用的なクラス名を誤って削除しないため）。
+func (p *HTMLParser) cleanContent(content string, base *url.URL, pf platform) (string, error) {
 	if content == "" {
 		return "", ErrEmptyContent
 	}
@@ -63,11 +81,41 @@ func (p *HTMLParser) CleanContent(content string) (string, error) {
 		return "", fmt.Errorf("%w: %v", ErrParseHTML, err)
 	}
 
+	// 埋め込みメディアはiframe・scriptの削除前にプレースホルダーへ変換する
+	convertEmbeds(doc)
+
+	// 数式・コードブロックはscriptの削除やハイライトの装飾で失われる前に元のTeX・言語を残す
+	normalizeMath(doc)
+	normalizeCodeBlocks(doc)
+
 	// 不要なタグを削除
 	for _, selector := range removeTags {
 		doc.Find(selector).Remove()
 	}
 
+	// コメント欄を削除（コメントはBlogPost.Commentsに別途抽出する）
+	for _, selector := range commentContainerSelectors(pf) {
+		doc.Find(selector).Remove()
+	}
+
+	// 前後の記事へのナビゲーション・関連記事を削除（BlogPostに別途抽出する）
+	for _, selector := range navigationRemoveSelectors(pf) {
+		doc.Find(selector).Remove()
+	}
+
+	// プラットフォーム固有の不要な要素（スター・共有ボタン・広告など）を削除
+	markup := articleMarkups[pf]
+	if markup.remove != "" {
+		doc.Find(markup.remove).Remove()
+	}
+
+	// 自動リンクはリンクを外してテキストだけを残す
+	if markup.unwrap != "" {
+		doc.Find(markup.unwrap).Each(func(i int, s *goquery.Selection) {
+			s.ReplaceWithSelection(s.Contents())
+		})
+	}
+
 	// アメブロ特有の不要な要素を削除
 	for parentSelector, childSelectors := range amebloRemoveSelectors {
 		doc.Find(parentSelector).Each(func(i int, s *goquery.Selection) {
@@ -77,6 +125,10 @@ func (p *HTMLParser) CleanContent(content string) (string, error) {
 		})
 	}
 
+	// 相対URLを絶対URLに変換してから書き換える
+	resolveURLs(doc, base)
+	p.rewriteURLs(doc)
+
 	// HTMLとして取得
 	html, err := doc.Find("body").Html()
 	if err != nil {
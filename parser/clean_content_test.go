@@ -173,3 +173,90 @@ func TestCleanContentEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestCleanContentScopesPlatformSelectors(t *testing.T) {
+	const body = `<div><p>本文の段落です。</p>` +
+		`<div class="hatena-star-container">スター</div>` +
+		`<a class="keyword" href="https://d.hatena.ne.jp/keyword/a">キーワード</a>` +
+		`<div class="post-footer">投稿者 まっく</div>` +
+		`<span class="entry_date">2009.03.05</span>` +
+		`<div class="paywall">有料部分の案内</div>` +
+		`<div class="sharedaddy">共有</div></div>`
+
+	tests := []struct {
+		name    string
+		pf      platform
+		removed []string
+		kept    []string
+	}{
+		{
+			name:    "不明なページは固有の要素を削除しない",
+			pf:      platformUnknown,
+			removed: nil,
+			kept:    []string{"スター", `class="keyword"`, "投稿者 まっく", "2009.03.05", "有料部分の案内", "共有"},
+		},
+		{
+			name:    "はてなブログのページは他のサービスの要素を残す",
+			pf:      platformHatena,
+			removed: []string{"スター", `class="keyword"`},
+			kept:    []string{"キーワード", "投稿者 まっく", "2009.03.05", "有料部分の案内", "共有"},
+		},
+		{
+			name:    "WordPressのページははてなキーワードのリンクを外さない",
+			pf:      platformWordPress,
+			removed: []string{"共有"},
+			kept:    []string{"スター", `class="keyword"`, "投稿者 まっく", "2009.03.05", "有料部分の案内"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&HTMLParser{}).cleanContent(body, nil, tt.pf)
+			if err != nil {
+				t.Fatalf("cleanContent() error = %v", err)
+			}
+			for _, s := range tt.removed {
+				if strings.Contains(got, s) {
+					t.Errorf("cleanContent() = %q, should not contain %q", got, s)
+				}
+			}
+			for _, s := range tt.kept {
+				if !strings.Contains(got, s) {
+					t.Errorf("cleanContent() = %q, should contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestCleanContentScopesLegacyBlogSelectors(t *testing.T) {
+	const body = `<div><p>本文の段落です。</p>` +
+		`<h3 class="entry_title">見出し</h3>` +
+		`<div class="entry_state">posted by まっく</div>` +
+		`<div class="entry-sns">共有ボタン</div>` +
+		`<div class="entryFooter">記事の末尾</div></div>`
+	all := []string{"見出し", "posted by まっく", "共有ボタン", "記事の末尾"}
+
+	tests := []struct {
+		pf      platform
+		removed []string
+	}{
+		{platformUnknown, nil},
+		{platformHatena, nil},
+		{platformJugem, []string{"見出し", "posted by まっく"}},
+		{platformGoo, []string{"共有ボタン"}},
+		{platformYahoo, []string{"記事の末尾"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.pf), func(t *testing.T) {
+			got, err := (&HTMLParser{}).cleanContent(body, nil, tt.pf)
+			if err != nil {
+				t.Fatalf("cleanContent() error = %v", err)
+			}
+			for _, s := range all {
+				if want := !containsString(tt.removed, s); strings.Contains(got, s) != want {
+					t.Errorf("cleanContent() = %q, contains %q = %v, want %v", got, s, !want, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// コードブロックの言語を表すclass属性（language-go、lang-go、highlight-source-go）
+var codeLanguageRe = regexp.MustCompile(`(?:^|\s)(?:language|lang|highlight-source)-([\w+#.-]+)`)
+
+// コードブロックを囲むプラットフォーム固有の要素と、その中のファイル名の要素
+var codeContainers = []struct {
+	container string
+	filename  string
+}{
+	{container: ".code-frame", filename: ".code-lang"},                     // Qiita
+	{container: ".code-block-container", filename: ".code-block-filename"}, // Zenn
+}
+
+// 数式として扱うコードブロックの言語
+const mathLanguage = "math"
+
+// normalizeCodeBlocks はシンタックスハイライトされたコードブロックを、
+// 言語とファイル名を保った<pre><code class="language-go" data-filename="main.go">形式に変換します。
+// 言語が分からずプラットフォーム固有の要素にも囲まれていないpreはそのまま残します。
+func normalizeCodeBlocks(doc *goquery.Document) {
+	doc.Find("pre").Each(func(i int, pre *goquery.Selection) {
+		target, filename := pre, ""
+		for _, c := range codeContainers {
+			if container := pre.Closest(c.container); container.Length() > 0 {
+				target = container
+				filename = strings.TrimSpace(container.Find(c.filename).First().Text())
+				break
+			}
+		}
+
+		lang := codeLanguage(pre, target)
+		if lang == "" && target == pre {
+			return
+		}
+		code := strings.TrimRight(pre.Text(), "\n")
+		if lang == mathLanguage {
+			target.ReplaceWithHtml(mathHTML(code, true))
+			return
+		}
+		// Qiitaのファイル名は言語の表記を兼ねることがある（「go:main.go」は言語の後ろにファイル名）
+		if filename == lang {
+			filename = ""
+		}
+
+		var b strings.Builder
+		b.WriteString("<pre><code")
+		if lang != "" {
+			b.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+		}
+		if filename != "" {
+			b.WriteString(` data-filename="` + html.EscapeString(filename) + `"`)
+		}
+		b.WriteString(">" + html.EscapeString(code) + "</code></pre>")
+		target.ReplaceWithHtml(b.String())
+	})
+}
+
+// codeLanguage はpre・code要素と、それを囲む要素のclass属性・data-lang属性からコードの言語を返します
+func codeLanguage(pre, container *goquery.Selection) string {
+	for _, s := range []*goquery.Selection{pre.Children().Filter("code").First(), pre, container} {
+		if lang, _, _ := strings.Cut(strings.TrimSpace(s.AttrOr("data-lang", "")), ":"); lang != "" {
+			return strings.ToLower(lang)
+		}
+		if matches := codeLanguageRe.FindStringSubmatch(s.AttrOr("class", "")); len(matches) > 1 {
+			return strings.ToLower(matches[1])
+		}
+	}
+	return ""
+}
+
+// normalizeMath はKaTeX・MathJaxで描画された数式を、元のTeXを$・$$で囲んだ要素に変換します。
+// 描画結果のHTMLやMathJaxのscriptは本文のクリーニングで失われるため、scriptの削除より前に呼び出します。
+func normalizeMath(doc *goquery.Document) {
+	// KaTeX（Zennなど）はannotationに元のTeXが残る
+	doc.Find(".katex").Each(func(i int, s *goquery.Selection) {
+		tex := strings.TrimSpace(s.Find("annotation[encoding='application/x-tex']").First().Text())
+		if tex == "" {
+			return
+		}
+		display := s.Closest(".katex-display").Length() > 0
+		target := s
+		if wrapper := s.Closest("embed-katex"); wrapper.Length() > 0 {
+			target = wrapper
+			display = display || wrapper.AttrOr("display-mode", "") == "1"
+		} else if display {
+			target = s.Closest(".katex-display")
+		}
+		target.ReplaceWithHtml(mathHTML(tex, display))
+	})
+
+	// Zennの未描画の数式はembed-katex内にTeXがそのまま残る（ブロックはeqn、インラインはeq）
+	doc.Find("embed-katex").Each(func(i int, s *goquery.Selection) {
+		display := s.AttrOr("display-mode", "") == "1" || s.Find("eqn").Length() > 0
+		s.ReplaceWithHtml(mathHTML(s.Text(), display))
+	})
+
+	// MathJax v2はscript[type="math/tex"]に元のTeXが残り、描画結果は直前の要素に出力される
+	doc.Find("script[type^='math/tex']").Each(func(i int, s *goquery.Selection) {
+		s.PrevAllFiltered(".MathJax_Preview, .MathJax, .MathJax_Display, .MathJax_SVG, .MathJax_SVG_Display").Remove()
+		display := strings.Contains(s.AttrOr("type", ""), "mode=display")
+		s.ReplaceWithHtml(mathHTML(strings.TrimSpace(s.Text()), display))
+	})
+}
+
+// mathHTML はTeXの数式を本文に残すための要素を返します（ブロックは$$、インラインは$で囲む）
+func mathHTML(tex string, display bool) string {
+	tex = html.EscapeString(strings.TrimSpace(tex))
+	if display {
+		return `<div class="math math-display">$$` + "\n" + tex + "\n" + `$$</div>`
+	}
+	return `<span class="math math-inline">$` + tex + `$</span>`
+}
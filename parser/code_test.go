@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestNormalizeCodeBlocks(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "Qiitaのcode-frame",
+			html: `<div class="code-frame" data-lang="go"><div class="code-lang"><span class="bold">main.go</span></div><div class="highlight"><pre><code><span class="kd">func</span> <span class="nx">main</span>() {}
+</code></pre></div></div>`,
+			want: `<pre><code class="language-go" data-filename="main.go">func main() {}</code></pre>`,
+		},
+		{
+			name: "Zennのcode-block-container",
+			html: `<div class="code-block-container"><div class="code-block-filename-container"><span class="code-block-filename">run.sh</span></div><pre class="language-sh"><code class="language-sh">echo &quot;a &lt; b&quot;</code></pre></div>`,
+			want: `<pre><code class="language-sh" data-filename="run.sh">echo &#34;a &lt; b&#34;</code></pre>`,
+		},
+		{
+			name: "ファイル名が言語名と同じ",
+			html: `<div class="code-frame" data-lang="ruby"><div class="code-lang"><span class="bold">ruby</span></div><div class="highlight"><pre><code>puts 1</code></pre></div></div>`,
+			want: `<pre><code class="language-ruby">puts 1</code></pre>`,
+		},
+		{
+			name: "highlight.jsのclass",
+			html: `<pre><code class="hljs language-Python">print(1)</code></pre>`,
+			want: `<pre><code class="language-python">print(1)</code></pre>`,
+		},
+		{
+			name: "mathのコードブロックは数式",
+			html: `<div class="code-frame" data-lang="math"><div class="highlight"><pre><code>e^{i\pi} + 1 = 0</code></pre></div></div>`,
+			want: `<div class="math math-display">$$
+e^{i\pi} + 1 = 0
+$$</div>`,
+		},
+		{
+			name: "言語が分からないpreはそのまま",
+			html: `<pre>  そのまま  </pre>`,
+			want: `<pre>  そのまま  </pre>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			normalizeCodeBlocks(doc)
+			got, _ := doc.Find("body").Html()
+			if got != tt.want {
+				t.Errorf("normalizeCodeBlocks() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeMath(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "KaTeXのインライン数式",
+			html: `<p>式<span class="katex"><span class="katex-mathml"><math><semantics><mrow><mi>x</mi></mrow><annotation encoding="application/x-tex">x^2</annotation></semantics></math></span><span class="katex-html" aria-hidden="true">x2</span></span>です</p>`,
+			want: `<p>式<span class="math math-inline">$x^2$</span>です</p>`,
+		},
+		{
+			name: "KaTeXのブロック数式",
+			html: `<p class="katex-display"><span class="katex"><span class="katex-mathml"><math><semantics><annotation encoding="application/x-tex">a &lt; b</annotation></semantics></math></span></span></p>`,
+			want: `<div class="math math-display">$$
+a &lt; b
+$$</div>`,
+		},
+		{
+			name: "Zennの未描画の数式",
+			html: `<p><embed-katex><eq class="zenn-katex">y = ax</eq></embed-katex></p><embed-katex display-mode="1"><eqn class="zenn-katex">\int_0^1 f(x)\,dx</eqn></embed-katex>`,
+			want: `<p><span class="math math-inline">$y = ax$</span></p><div class="math math-display">$$
+\int_0^1 f(x)\,dx
+$$</div>`,
+		},
+		{
+			name: "MathJax v2",
+			html: `<p><span class="MathJax_Preview"></span><span class="MathJax">描画結果</span><script type="math/tex">\alpha</script></p><div class="MathJax_Display">描画結果</div><script type="math/tex; mode=display">\beta</script>`,
+			want: `<p><span class="math math-inline">$\alpha$</span></p><div class="math math-display">$$
+\beta
+$$</div>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			normalizeMath(doc)
+			got, _ := doc.Find("body").Html()
+			if got != tt.want {
+				t.Errorf("normalizeMath() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
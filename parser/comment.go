@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// commentMarkup はコメント欄のマークアップを表すセレクタの組です
+type commentMarkup struct {
+	container string         // コメント欄全体（本文からは削除する）
+	item      string         // 各コメント（返信コメントも同じセレクタで入れ子になる）
+	author    string         // 投稿者名
+	authorRe  *regexp.Regexp // 投稿者名の要素がない場合に、日時のテキストから投稿者名を取り出す正規表現
+	body      string         // コメント本文
+	date      string         // 投稿日時
+}
+
+// プラットフォームごとのコメント欄のマークアップ
+var commentMarkups = map[platform]commentMarkup{
+	platformAmeblo: {
+		container: "[data-uranus-component='commentList'], .skin-commentList",
+		item:      "[data-uranus-component='commentItem'], .skin-commentItem",
+		author:    "[data-uranus-component='commentAuthor'], .skin-commentAuthor",
+		body:      "[data-uranus-component='commentText'], .skin-commentText",
+		date:      "time, [data-uranus-component='commentDate'], .skin-commentDate",
+	},
+	platformLivedoor: {
+		container: "#comments, #comments-list, .comments-list",
+		item:      "li.comment, div.comment",
+		author:    ".comment-author",
+		body:      ".comment-body",
+		date:      ".comment-date",
+	},
+	platformExcite: {
+		container: "#comment_area, .COMMENT_AREA, div.COMMENT",
+		item:      "div.COMMENT",
+		authorRe:  regexp.MustCompile(`by\s+(.+?)\s+at\s`),
+		body:      ".COMMENT_BODY",
+		date:      ".COMMENT_TAIL",
+	},
+	platformBlogger: {
+		container: "#comments, .comments, .comment-thread",
+		item:      "li.comment",
+		author:    ".comment-header .user, .user",
+		body:      ".comment-content, p.comment-content",
+		date:      ".comment-header .datetime, .datetime",
+	},
+	platformHatena: {
+		container: ".comment-box",
+		item:      "li.entry-comment",
+		author:    ".comment-user-name .hatena-id-link, .comment-user-name",
+		body:      ".comment-content",
+		date:      ".comment-metadata time",
+	},
+}
+
+// プラットフォームを判定できない場合のコメント欄のマークアップ（WordPress形式）
+var genericCommentMarkup = commentMarkup{
+	container: "#comments, .comments-area, .commentlist, .comment-list",
+	item:      "li.comment",
+	author:    ".comment-author .fn, .comment-author",
+	body:      ".comment-content, .comment-body",
+	date:      ".comment-metadata time, .comment-meta time, time, .comment-date",
+}
+
+// コメント投稿者名に付与される定型の接頭辞
+var commentAuthorPrefixRe = regexp.MustCompile(`^(?:\d+\.\s*)?(?:Posted by|Commented by|by)?\s*`)
+
+// 投稿日時を日本時間で表記する国内のブログサービス
+var japanesePlatforms = map[platform]bool{
+	platformAmeblo:   true,
+	platformLivedoor: true,
+	platformExcite:   true,
+	platformHatena:   true,
+	platformNote:     true,
+	platformSeesaa:   true,
+	platformJugem:    true,
+	platformGoo:      true,
+	platformYahoo:    true,
+}
+
+// extractComments はHTMLドキュメントからコメントを抽出します。
+// プラットフォーム固有のマークアップで見つからない場合は、一般的なマークアップで抽出を試みます。
+// タイムゾーンの表記がない投稿日時は、国内のブログサービスでは日本時間、それ以外ではUTCとして扱います。
+func extractComments(page *articlePage) []models.Comment {
+	doc := page.doc
+	pf := page.platform
+	loc := time.UTC
+	if japanesePlatforms[pf] {
+		loc = jstLocation
+	}
+	if markup, ok := commentMarkups[pf]; ok {
+		if comments := extractCommentsWith(doc, markup, loc); len(comments) > 0 {
+			return comments
+		}
+	}
+	return extractCommentsWith(doc, genericCommentMarkup, loc)
+}
+
+// extractCommentsWith は指定したマークアップでコメントを抽出します
+func extractCommentsWith(doc *goquery.Document, markup commentMarkup, loc *time.Location) []models.Comment {
+	// 他のコメントの内側にない最上位のコメントのみを対象にする
+	items := doc.Find(markup.item).FilterFunction(func(i int, s *goquery.Selection) bool {
+		return s.ParentsFiltered(markup.item).Length() == 0
+	})
+	return commentsFrom(items, markup, loc)
+}
+
+// commentsFrom はコメント要素の一覧から返信を含むコメントを組み立てます
+func commentsFrom(items *goquery.Selection, markup commentMarkup, loc *time.Location) []models.Comment {
+	var comments []models.Comment
+	items.Each(func(i int, item *goquery.Selection) {
+		comment := models.Comment{
+			Body: commentText(ownElement(item, markup.body, markup.item)),
+		}
+
+		if author := ownElement(item, markup.author, markup.item); author.Length() > 0 {
+			comment.Author = cleanCommentAuthor(author.Text())
+			link := author.Filter("a[href]")
+			if link.Length() == 0 {
+				link = author.Find("a[href]").First()
+			}
+			comment.AuthorURL = strings.TrimSpace(link.AttrOr("href", ""))
+		}
+
+		if date := ownElement(item, markup.date, markup.item); date.Length() > 0 {
+			text := date.AttrOr("datetime", "")
+			if text == "" {
+				text = date.Text()
+			}
+			if t, err := parseDateStringInLocation(strings.TrimSpace(text), loc); err == nil {
+				comment.CreatedAt = t
+			} else if t, ok := extractDateFromTextInLocation(text, loc); ok {
+				comment.CreatedAt = t
+			}
+			if comment.Author == "" && markup.authorRe != nil {
+				if matches := markup.authorRe.FindStringSubmatch(strings.Join(strings.Fields(date.Text()), " ")); len(matches) > 1 {
+					comment.Author = strings.TrimSpace(matches[1])
+				}
+			}
+		}
+
+		// 直下の返信コメント
+		replies := item.Find(markup.item).FilterFunction(func(j int, s *goquery.Selection) bool {
+			return s.Parent().Closest(markup.item).IsSelection(item)
+		})
+		comment.Replies = commentsFrom(replies, markup, loc)
+
+		if comment.Body != "" || len(comment.Replies) > 0 {
+			comments = append(comments, comment)
+		}
+	})
+	return comments
+}
+
+// ownElement はitem内で、入れ子の返信コメントに含まれない最初の要素を返します。
+// カンマ区切りのセレクタは記述した順に優先して探します。
+func ownElement(item *goquery.Selection, selector, itemSelector string) *goquery.Selection {
+	for _, sel := range strings.Split(selector, ",") {
+		if sel = strings.TrimSpace(sel); sel == "" {
+			continue
+		}
+		found := item.Find(sel).FilterFunction(func(i int, s *goquery.Selection) bool {
+			return s.Closest(itemSelector).IsSelection(item)
+		}).First()
+		if found.Length() > 0 {
+			return found
+		}
+	}
+	return item.Slice(0, 0)
+}
+
+// commentText はコメント本文の要素から改行を保ったテキストを取り出します
+func commentText(s *goquery.Selection) string {
+	if s.Length() == 0 {
+		return ""
+	}
+	s = s.Clone()
+	s.Find("br").ReplaceWithHtml("\n")
+	s.Find("p, div").AppendHtml("\n")
+
+	var lines []string
+	for _, line := range strings.Split(s.Text(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// htmlCommentBody はエクスポートファイルのHTMLを含むコメント本文を改行を保ったテキストに変換します
+func htmlCommentBody(content string) string {
+	content = strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\n", "<br>")
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+	return commentText(doc.Find("body"))
+}
+
+// cleanCommentAuthor はコメント投稿者名から番号や定型の接頭辞を除去します
+func cleanCommentAuthor(author string) string {
+	author = strings.Join(strings.Fields(author), " ")
+	author = commentAuthorPrefixRe.ReplaceAllString(author, "")
+	return strings.TrimSpace(author)
+}
+
+// commentContainerSelectors は本文から削除するコメント欄のセレクタを返します。
+// 汎用のコメント欄と、pfのブログサービス固有のコメント欄だけを対象にします。
+func commentContainerSelectors(pf platform) []string {
+	selectors := []string{genericCommentMarkup.container}
+	if markup, ok := commentMarkups[pf]; ok {
+		selectors = append(selectors, markup.container)
+	}
+	return selectors
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestExtractComments(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []models.Comment
+	}{
+		{
+			name: "livedoor",
+			html: `<script>var ld_blog_vars = {};</script>
+<section id="comments"><ol class="comments-list">
+  <li class="comment">
+    <div class="comment-body">面白い<br>続きが楽しみです</div>
+    <ul class="comment-info">
+      <li class="comment-author">1. Posted by <a href="http://example.com/">名無し</a></li>
+      <li class="comment-date">2018年06月17日 03:00</li>
+    </ul>
+  </li>
+  <li class="comment">
+    <div class="comment-body">二件目</div>
+    <ul class="comment-info"><li class="comment-author">2. 通りすがり</li><li class="comment-date">2018/06/18 10:05</li></ul>
+  </li>
+</ol></section>`,
+			want: []models.Comment{
+				{Author: "名無し", AuthorURL: "http://example.com/", Body: "面白い\n続きが楽しみです", CreatedAt: time.Date(2018, 6, 17, 3, 0, 0, 0, jstLocation)},
+				{Author: "通りすがり", Body: "二件目", CreatedAt: time.Date(2018, 6, 18, 10, 5, 0, 0, jstLocation)},
+			},
+		},
+		{
+			name: "エキサイトブログ",
+			html: `<link rel="canonical" href="https://kapparin.exblog.jp/16274503/">
+<div class="COMMENT">
+  <div class="COMMENT_BODY">素敵な写真ですね</div>
+  <div class="COMMENT_TAIL">Commented by <a href="https://example.exblog.jp/">ゆき</a> at 2011-09-13 08:12 <a href="#">x</a></div>
+</div>`,
+			want: []models.Comment{
+				{Author: "ゆき", Body: "素敵な写真ですね", CreatedAt: time.Date(2011, 9, 13, 8, 12, 0, 0, jstLocation)},
+			},
+		},
+		{
+			name: "アメブロ",
+			html: `<link rel="canonical" href="https://ameblo.jp/user/entry-1.html">
+<ul data-uranus-component="commentList">
+  <li data-uranus-component="commentItem">
+    <span data-uranus-component="commentAuthor">読者A</span>
+    <time datetime="2024-05-22T13:00:00+09:00">2024-05-22 13:00</time>
+    <p data-uranus-component="commentText">ありがとうございます</p>
+  </li>
+</ul>`,
+			want: []models.Comment{
+				{Author: "読者A", Body: "ありがとうございます", CreatedAt: time.Date(2024, 5, 22, 13, 0, 0, 0, jstLocation)},
+			},
+		},
+		{
+			name: "Seesaaブログ（WordPress形式のマークアップ）",
+			html: `<link rel="canonical" href="http://example.seesaa.net/article/115123456.html">
+<ol class="comment-list">
+  <li class="comment"><div class="comment-author">通りすがり</div><div class="comment-body">参考になりました</div><div class="comment-date">2009/03/06 10:00</div></li>
+</ol>`,
+			want: []models.Comment{
+				{Author: "通りすがり", Body: "参考になりました", CreatedAt: time.Date(2009, 3, 6, 10, 0, 0, 0, jstLocation)},
+			},
+		},
+		{
+			name: "返信の入れ子（WordPress形式）",
+			html: `<ol class="comment-list">
+  <li class="comment">
+    <article class="comment-body">
+      <div class="comment-author"><b class="fn"><a href="https://a.example.com/">Alice</a></b></div>
+      <div class="comment-metadata"><time datetime="2024-01-02T03:04:05Z">2024年1月2日</time></div>
+      <div class="comment-content"><p>最初のコメント</p></div>
+    </article>
+    <ol class="children">
+      <li class="comment">
+        <article class="comment-body">
+          <div class="comment-author"><b class="fn">Bob</b></div>
+          <div class="comment-content"><p>返信です</p></div>
+        </article>
+        <ol class="children">
+          <li class="comment"><article class="comment-body"><div class="comment-author"><b class="fn">Alice</b></div><div class="comment-content"><p>返信への返信</p></div></article></li>
+        </ol>
+      </li>
+    </ol>
+  </li>
+  <li class="comment"><article class="comment-body"><div class="comment-author"><b class="fn">Carol</b></div><div class="comment-content"><p>二件目</p></div></article></li>
+</ol>`,
+			want: []models.Comment{
+				{
+					Author: "Alice", AuthorURL: "https://a.example.com/", Body: "最初のコメント",
+					CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					Replies: []models.Comment{
+						{Author: "Bob", Body: "返信です", Replies: []models.Comment{
+							{Author: "Alice", Body: "返信への返信"},
+						}},
+					},
+				},
+				{Author: "Carol", Body: "二件目"},
+			},
+		},
+		{
+			name: "コメントなし",
+			html: `<article><p>本文</p></article>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := extractComments(newArticlePage(doc))
+			if len(got) != len(tt.want) {
+				t.Fatalf("extractComments() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				assertComment(t, got[i], tt.want[i])
+			}
+		})
+	}
+}
+
+// assertComment は日時をEqualで比較しながらコメントを比較します
+func assertComment(t *testing.T, got, want models.Comment) {
+	t.Helper()
+	if !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, want.CreatedAt)
+	}
+	if len(got.Replies) != len(want.Replies) {
+		t.Fatalf("Replies = %+v, want %+v", got.Replies, want.Replies)
+	}
+	for i := range got.Replies {
+		assertComment(t, got.Replies[i], want.Replies[i])
+	}
+	got.CreatedAt, want.CreatedAt = time.Time{}, time.Time{}
+	got.Replies, want.Replies = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("comment = %+v, want %+v", got, want)
+	}
+}
+
+func TestCleanContentRemovesComments(t *testing.T) {
+	input := `<div class="post"><p>本文</p>` +
+		`<section id="comments"><ol class="comment-list"><li class="comment">コメント</li></ol></section>` +
+		`<div class="COMMENT"><div class="COMMENT_BODY">コメント</div></div>` +
+		`<ul class="comments"><li>見出しの一覧</li></ul></div>`
+
+	tests := []struct {
+		pf   platform
+		want string
+	}{
+		{platformUnknown, `<div class="post"><p>本文</p><div class="COMMENT"><div class="COMMENT_BODY">コメント</div></div><ul class="comments"><li>見出しの一覧</li></ul></div>`},
+		{platformExcite, `<div class="post"><p>本文</p><ul class="comments"><li>見出しの一覧</li></ul></div>`},
+		{platformBlogger, `<div class="post"><p>本文</p><div class="COMMENT"><div class="COMMENT_BODY">コメント</div></div></div>`},
+	}
+	for _, tt := range tests {
+		got, err := (&HTMLParser{}).cleanContent(input, nil, tt.pf)
+		if err != nil {
+			t.Fatalf("cleanContent() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("cleanContent(%q) = %v, want %v", tt.pf, got, tt.want)
+		}
+	}
+}
@@ -4,16 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-
-	"github.com/PuerkitoBio/goquery"
 )
 
 // extractContent はHTMLドキュメントから記事の本文を抽出します。
-// 以下の優先順位で抽出を試みます：
+// プラットフォーム固有のマークアップ（articleMarkups）がある場合はそのセレクタを最初に試し、
+// 続けて以下の優先順位で抽出を試みます：
 // 1. article タグ内のコンテンツ
 // 2. main タグ内のコンテンツ
 // 3. .content または .article クラスを持つ要素のコンテンツ
-func extractContent(doc *goquery.Document) (string, error) {
+func extractContent(page *articlePage) (string, error) {
+	doc := page.doc
 	if doc == nil {
 		return "", errors.New("ドキュメントがnilです")
 	}
@@ -21,14 +21,22 @@ func extractContent(doc *goquery.Document) (string, error) {
 	var extractionAttempts []string
 
 	// よく使用されるブログプラットフォームのセレクター
-	selectors := []string{
+	var selectors []string
+	if page.hasMarkup {
+		// カンマ区切りのままFindすると文書内の順で最初の要素が選ばれるため、記述した順に分けて試す
+		for _, sel := range strings.Split(page.markup.content, ",") {
+			if sel = strings.TrimSpace(sel); sel != "" {
+				selectors = append(selectors, sel)
+			}
+		}
+	}
+	selectors = append(selectors,
 		"div.article-body-inner",
 		"div.skin-entryBody",
 		"div.articleText",
 		"div.post-main",
 		"div.post-body",
 		"div.entry-content",
-		"div.POST_BODY",
 		"article",
 		"[itemprop='articleBody']",
 		".entry-content",
@@ -37,7 +45,7 @@ func extractContent(doc *goquery.Document) (string, error) {
 		"#content",
 		"#main-content",
 		".content",
-	}
+	)
 
 	// 指定されたセレクターで抽出を試みる
 	for _, selector := range selectors {
@@ -115,7 +115,7 @@ func TestExtractContent(t *testing.T) {
 				}
 			}
 
-			result, err := extractContent(doc)
+			result, err := extractContent(newArticlePage(doc))
 			
 			if tt.wantErr {
 				if err == nil {
@@ -3,6 +3,8 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,18 +12,29 @@ import (
 )
 
 // extractDate はHTMLドキュメントから公開日時を抽出します。
-// 以下の優先順位で抽出を試みます：
+// プラットフォーム固有のマークアップ（articleMarkups）やscriptに埋め込まれた記事データで見つかった場合はそれを優先し、
+// 見つからない場合は以下の優先順位で抽出を試みます：
 // 1. <time datetime="...">
 // 2. <meta property="article:published_time" content="...">
 // 3. <meta name="pubdate" content="...">
 // 4. <meta name="date" content="...">
 // 5. <span class="date">...</span> など
 // 6. script[type="application/ld+json"]内の"datePublished"
-func extractDate(doc *goquery.Document) (time.Time, error) {
+func extractDate(page *articlePage) (time.Time, error) {
+	doc := page.doc
 	if doc == nil {
 		return time.Time{}, errors.New("ドキュメントがnilです")
 	}
 
+	if page.hasMarkup {
+		if t, found := markupDate(doc, page.markup.date); found {
+			return t, nil
+		}
+		if t := page.meta.date; !t.IsZero() {
+			return t, nil
+		}
+	}
+
 	// 1. script[type="application/ld+json"]内の"datePublished"
 	var datePublished string
 	doc.Find("script[type='application/ld+json']").Each(func(i int, s *goquery.Selection) {
@@ -89,6 +102,118 @@ func extractDate(doc *goquery.Document) (time.Time, error) {
 	return time.Time{}, errors.New("公開日時が見つかりません")
 }
 
+// extractUpdatedDate はHTMLドキュメントから更新日時を抽出します。
+// プラットフォーム固有の記事データ、JSON-LDのdateModified、
+// <meta property="article:modified_time">の順に参照し、見つからない場合はゼロ値を返します。
+func extractUpdatedDate(page *articlePage) time.Time {
+	doc := page.doc
+	if doc == nil {
+		return time.Time{}
+	}
+	if t := page.meta.updated; !t.IsZero() {
+		return t
+	}
+	if article := jsonLDArticle(doc); article != nil {
+		if t, err := parseDateString(jsonString(article["dateModified"])); err == nil {
+			return t
+		}
+	}
+	if t, err := parseDateString(doc.Find("meta[property='article:modified_time']").First().AttrOr("content", "")); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// 文章中に含まれる日時（2011-09-13 08:12、2018年06月17日 03:00 など）
+var dateInTextRe = regexp.MustCompile(`(\d{4})\s*[-/.年]\s*(\d{1,2})\s*[-/.月]\s*(\d{1,2})日?(?:\s*(?:\([^)]*\)|（[^）]*）))?(?:\s*(\d{1,2})\s*[:時]\s*(\d{1,2})分?(?:\s*[:]\s*(\d{1,2}))?)?`)
+
+// extractDateFromText は文章中から最初に見つかった日時をUTCとして取り出します。
+// 曜日の表記（(木) など）を含む日付や、時刻のない日付にも対応します。
+func extractDateFromText(text string) (time.Time, bool) {
+	return extractDateFromTextInLocation(text, time.UTC)
+}
+
+// extractDateFromTextInLocation は文章中から最初に見つかった日時を、locのタイムゾーンの日時として取り出します
+func extractDateFromTextInLocation(text string, loc *time.Location) (time.Time, bool) {
+	matches := dateInTextRe.FindStringSubmatch(text)
+	if matches == nil {
+		return time.Time{}, false
+	}
+
+	var parts [6]int
+	for i, m := range matches[1:] {
+		if m != "" {
+			parts[i], _ = strconv.Atoi(m)
+		}
+	}
+	year, month, day, hour, minute, second := parts[0], parts[1], parts[2], parts[3], parts[4], parts[5]
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, loc), true
+}
+
+// 英語の言語設定の日付の表記（曜日を除いたもの）
+var localizedDateLayouts = []string{
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"1月 2, 2006",
+	"1/2/2006",
+}
+
+var (
+	// 日付の先頭の英語の曜日（Sunday, など）
+	weekdayPrefixRe = regexp.MustCompile(`^[A-Za-z]+day,\s*`)
+	// 時刻の表記（18:18、6:18 PM、午後6:18、午後 0:34）
+	localizedTimeRe = regexp.MustCompile(`(午前|午後)?\s*(\d{1,2}):(\d{2})(?::(\d{2}))?\s*([AaPp])?\.?[Mm]?\.?`)
+)
+
+// 国内のブログサービスがタイムゾーンを付けずに表記する日時のタイムゾーン
+var jstLocation = time.FixedZone("JST", 9*60*60)
+
+// parseDateWithTime は別々に表記された日付と時刻のテキストを、locのタイムゾーンの日時に変換します（失敗した場合はゼロ値）。
+// ブログサービスの言語設定に応じた表記（2025年4月13日日曜日・Sunday, April 13, 2025、午後6:18・6:18 PM）に対応します。
+// 日付と時刻が同じテキストにある場合は、両方に同じテキストを渡します。
+func parseDateWithTime(dateText, timeText string, loc *time.Location) time.Time {
+	var date time.Time
+	if t, ok := extractDateFromText(dateText); ok {
+		date = t
+	} else {
+		dateText = weekdayPrefixRe.ReplaceAllString(strings.Join(strings.Fields(dateText), " "), "")
+		for _, layout := range localizedDateLayouts {
+			if t, err := time.Parse(layout, dateText); err == nil {
+				date = t
+				break
+			}
+		}
+	}
+	if date.IsZero() {
+		return time.Time{}
+	}
+
+	date = time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), 0, loc)
+
+	matches := localizedTimeRe.FindStringSubmatch(timeText)
+	if matches == nil {
+		return date
+	}
+	hour, _ := strconv.Atoi(matches[2])
+	minute, _ := strconv.Atoi(matches[3])
+	second, _ := strconv.Atoi(matches[4])
+	pm := matches[1] == "午後" || strings.EqualFold(matches[5], "p")
+	am := matches[1] == "午前" || strings.EqualFold(matches[5], "a")
+	if pm && hour < 12 {
+		hour += 12
+	} else if am && hour == 12 {
+		hour = 0
+	}
+	if hour > 23 || minute > 59 || second > 59 {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, second, 0, loc)
+}
+
 // extractDatePublishedFromJSONLDはJSON-LDテキストから"datePublished"値を抽出する
 func extractDatePublishedFromJSONLD(jsonText string) string {
 	idx := strings.Index(jsonText, "\"datePublished\"")
@@ -111,6 +236,12 @@ func extractDatePublishedFromJSONLD(jsonText string) string {
 
 // parseDateString は様々な日付文字列をtime.Timeに変換します。
 func parseDateString(s string) (time.Time, error) {
+	return parseDateStringInLocation(s, time.UTC)
+}
+
+// parseDateStringInLocation は様々な日付文字列をtime.Timeに変換します。
+// タイムゾーンの表記がない日時はlocのタイムゾーンの日時として扱います。
+func parseDateStringInLocation(s string, loc *time.Location) (time.Time, error) {
 	// よく使われる日付フォーマットを試す
 	layouts := []string{
 		time.RFC3339,
@@ -129,7 +260,7 @@ func parseDateString(s string) (time.Time, error) {
 		"2006-01-02 15:04",
 	}
 	for _, layout := range layouts {
-		if t, err := time.Parse(layout, s); err == nil {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
 			return t, nil
 		}
 	}
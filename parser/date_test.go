@@ -114,7 +114,7 @@ func TestExtractDate(t *testing.T) {
 				}
 			}
 
-			result, err := extractDate(doc)
+			result, err := extractDate(newArticlePage(doc))
 			
 			if tt.wantErr {
 				if err == nil {
@@ -267,4 +267,86 @@ func TestParseDateString(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestExtractDateFromText(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   time.Time
+		wantOK bool
+	}{
+		{"by ゆき at 2011-09-13 08:12 x", time.Date(2011, 9, 13, 8, 12, 0, 0, time.UTC), true},
+		{"2018年06月17日 03:00", time.Date(2018, 6, 17, 3, 0, 0, 0, time.UTC), true},
+		{"2009年03月05日(木) 12:34", time.Date(2009, 3, 5, 12, 34, 0, 0, time.UTC), true},
+		{"投稿日：2024/5/1", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2024.05.01 10:20:30", time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC), true},
+		{"2024-13-01", time.Time{}, false},
+		{"日付なし", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := extractDateFromText(tt.text)
+		if ok != tt.wantOK || !got.Equal(tt.want) {
+			t.Errorf("extractDateFromText(%q) = (%v, %v), want (%v, %v)", tt.text, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseDateWithTime(t *testing.T) {
+	tests := []struct {
+		dateText string
+		timeText string
+		want     time.Time
+	}{
+		{"2025年4月13日日曜日", "午後6:18", time.Date(2025, 4, 13, 18, 18, 0, 0, jstLocation)},
+		{"2025年4月13日日曜日", "午前12:05", time.Date(2025, 4, 13, 0, 5, 0, 0, jstLocation)},
+		{"Sunday, April 13, 2025", "6:18 PM", time.Date(2025, 4, 13, 18, 18, 0, 0, jstLocation)},
+		{"April 13, 2025", "18:18:05", time.Date(2025, 4, 13, 18, 18, 5, 0, jstLocation)},
+		{"4月 13, 2025", "", time.Date(2025, 4, 13, 0, 0, 0, 0, jstLocation)},
+		{"2009/3/5(木) 午後 0:34", "2009/3/5(木) 午後 0:34", time.Date(2009, 3, 5, 12, 34, 0, 0, jstLocation)},
+		{"2009年03月05日(木) 12:34", "2009年03月05日(木) 12:34", time.Date(2009, 3, 5, 12, 34, 0, 0, jstLocation)},
+		{"2009.03.05 Thursday", "posted by まっく | 23:05 | comments(0)", time.Date(2009, 3, 5, 23, 5, 0, 0, jstLocation)},
+		{"", "6:18 PM", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dateText+" "+tt.timeText, func(t *testing.T) {
+			if got := parseDateWithTime(tt.dateText, tt.timeText, jstLocation); !got.Equal(tt.want) {
+				t.Errorf("parseDateWithTime(%q, %q) = %v, want %v", tt.dateText, tt.timeText, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractUpdatedDate(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want time.Time
+	}{
+		{
+			name: "JSON-LDのdateModified",
+			html: `<script type="application/ld+json">{"@type":"BlogPosting","datePublished":"2024-05-01T10:00:00Z","dateModified":"2024-05-02T10:00:00Z"}</script>`,
+			want: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "article:modified_time",
+			html: `<meta property="article:modified_time" content="2024-05-03T10:00:00Z">`,
+			want: time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "更新日時なし",
+			html: `<time datetime="2024-05-01T10:00:00Z">2024/05/01</time>`,
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := extractUpdatedDate(newArticlePage(doc)); !got.Equal(tt.want) {
+				t.Errorf("extractUpdatedDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
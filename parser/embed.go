@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// 埋め込みメディアの提供元
+const (
+	EmbedYouTube   = "youtube"
+	EmbedTwitter   = "twitter"
+	EmbedInstagram = "instagram"
+	EmbedSpotify   = "spotify"
+	EmbedAmeblo    = "ameblo"
+)
+
+// 埋め込みメディアのプレースホルダー要素
+const embedPlaceholderSelector = "figure[data-embed-provider]"
+
+var (
+	// YouTubeの埋め込みURLのパス（/embed/動画ID）
+	youtubeEmbedPathRe = regexp.MustCompile(`^/embed/([\w-]+)`)
+	// Spotifyの埋め込みURLのパス（/embed/種別/ID）
+	spotifyEmbedPathRe = regexp.MustCompile(`^/embed(?:-podcast)?/(track|album|playlist|artist|episode|show)/(\w+)`)
+	// X/TwitterのポストURLのパス（/ユーザー名/status/ID）
+	tweetPathRe = regexp.MustCompile(`/status(?:es)?/(\d+)`)
+	// InstagramのポストURLのパス（/p/ID/、/reel/ID/）
+	instagramPathRe = regexp.MustCompile(`/(p|reel|tv)/([\w-]+)`)
+)
+
+// iframeのsrcから埋め込みメディアを判定するルール
+var iframeEmbedRules = []struct {
+	provider string
+	match    func(u *url.URL) (id, mediaURL string, ok bool)
+}{
+	{EmbedYouTube, matchYouTubeEmbed},
+	{EmbedTwitter, matchTwitterEmbed},
+	{EmbedInstagram, matchInstagramEmbed},
+	{EmbedSpotify, matchSpotifyEmbed},
+	{EmbedAmeblo, matchAmebloVideoEmbed},
+}
+
+// convertEmbeds は既知の埋め込みメディアをプレースホルダーに置き換えます。
+// iframeやscriptを削除する前に呼び出すことで、埋め込みメディアが失われるのを防ぎます。
+func convertEmbeds(doc *goquery.Document) {
+	// iframeによる埋め込み
+	doc.Find("iframe[src]").Each(func(i int, s *goquery.Selection) {
+		src := strings.TrimSpace(s.AttrOr("src", ""))
+		if strings.HasPrefix(src, "//") {
+			src = "https:" + src
+		}
+		u, err := url.Parse(src)
+		if err != nil || u.Host == "" {
+			return
+		}
+		for _, rule := range iframeEmbedRules {
+			if id, mediaURL, ok := rule.match(u); ok {
+				replaceWithEmbedPlaceholder(s, models.Embed{Provider: rule.provider, ID: id, URL: mediaURL})
+				return
+			}
+		}
+	})
+
+	// X/Twitterの埋め込み（blockquote.twitter-tweet）
+	doc.Find("blockquote.twitter-tweet").Each(func(i int, s *goquery.Selection) {
+		s.Find("a[href]").EachWithBreak(func(j int, a *goquery.Selection) bool {
+			href := a.AttrOr("href", "")
+			matches := tweetPathRe.FindStringSubmatch(href)
+			if len(matches) < 2 {
+				return true
+			}
+			replaceWithEmbedPlaceholder(s, models.Embed{
+				Provider: EmbedTwitter,
+				ID:       matches[1],
+				URL:      stripQuery(href),
+			})
+			return false
+		})
+	})
+
+	// Instagramの埋め込み（blockquote.instagram-media）
+	doc.Find("blockquote.instagram-media").Each(func(i int, s *goquery.Selection) {
+		permalink := s.AttrOr("data-instgrm-permalink", "")
+		if permalink == "" {
+			permalink = s.Find("a[href*='instagram.com']").First().AttrOr("href", "")
+		}
+		matches := instagramPathRe.FindStringSubmatch(permalink)
+		if len(matches) < 3 {
+			return
+		}
+		replaceWithEmbedPlaceholder(s, models.Embed{
+			Provider: EmbedInstagram,
+			ID:       matches[2],
+			URL:      stripQuery(permalink),
+		})
+	})
+}
+
+// replaceWithEmbedPlaceholder は埋め込み要素をリンクを含むプレースホルダーに置き換えます。
+// プレースホルダーはスクリプトなしでも元のメディアへのリンクとして機能します。
+func replaceWithEmbedPlaceholder(s *goquery.Selection, embed models.Embed) {
+	figure := `<figure class="embed embed-` + embed.Provider + `"` +
+		` data-embed-provider="` + embed.Provider + `"` +
+		` data-embed-id="` + html.EscapeString(embed.ID) + `">` +
+		`<a href="` + html.EscapeString(embed.URL) + `">` + html.EscapeString(embed.URL) + `</a></figure>`
+	s.ReplaceWithHtml(figure)
+}
+
+// ExtractEmbeds は本文HTMLのプレースホルダーから埋め込みメディアの一覧を抽出します。
+func (p *HTMLParser) ExtractEmbeds(content string) []models.Embed {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil
+	}
+
+	var embeds []models.Embed
+	doc.Find(embedPlaceholderSelector).Each(func(i int, s *goquery.Selection) {
+		embeds = append(embeds, models.Embed{
+			Provider: s.AttrOr("data-embed-provider", ""),
+			ID:       s.AttrOr("data-embed-id", ""),
+			URL:      s.Find("a[href]").First().AttrOr("href", ""),
+		})
+	})
+	return embeds
+}
+
+// matchYouTubeEmbed はYouTubeの埋め込みURLを判定します
+func matchYouTubeEmbed(u *url.URL) (string, string, bool) {
+	host := strings.TrimPrefix(u.Host, "www.")
+	if host != "youtube.com" && host != "youtube-nocookie.com" {
+		return "", "", false
+	}
+	matches := youtubeEmbedPathRe.FindStringSubmatch(u.Path)
+	if len(matches) < 2 {
+		return "", "", false
+	}
+	return matches[1], "https://www.youtube.com/watch?v=" + matches[1], true
+}
+
+// matchTwitterEmbed はX/Twitterの埋め込みURLを判定します
+func matchTwitterEmbed(u *url.URL) (string, string, bool) {
+	if u.Host != "platform.twitter.com" && u.Host != "platform.x.com" {
+		return "", "", false
+	}
+	id := u.Query().Get("id")
+	if id == "" {
+		return "", "", false
+	}
+	return id, "https://twitter.com/i/status/" + id, true
+}
+
+// matchInstagramEmbed はInstagramの埋め込みURLを判定します
+func matchInstagramEmbed(u *url.URL) (string, string, bool) {
+	if strings.TrimPrefix(u.Host, "www.") != "instagram.com" {
+		return "", "", false
+	}
+	matches := instagramPathRe.FindStringSubmatch(u.Path)
+	if len(matches) < 3 {
+		return "", "", false
+	}
+	return matches[2], "https://www.instagram.com/" + matches[1] + "/" + matches[2] + "/", true
+}
+
+// matchSpotifyEmbed はSpotifyの埋め込みURLを判定します
+func matchSpotifyEmbed(u *url.URL) (string, string, bool) {
+	if u.Host != "open.spotify.com" {
+		return "", "", false
+	}
+	matches := spotifyEmbedPathRe.FindStringSubmatch(u.Path)
+	if len(matches) < 3 {
+		return "", "", false
+	}
+	return matches[2], "https://open.spotify.com/" + matches[1] + "/" + matches[2], true
+}
+
+// matchAmebloVideoEmbed はアメブロの動画埋め込みURLを判定します
+func matchAmebloVideoEmbed(u *url.URL) (string, string, bool) {
+	if u.Host != "static.blog-video.jp" && !strings.HasSuffix(u.Host, ".blog-video.jp") {
+		return "", "", false
+	}
+	id := u.Query().Get("v")
+	if id == "" {
+		return "", "", false
+	}
+	u.Scheme = "https"
+	return id, u.String(), true
+}
+
+// stripQuery はURLからクエリ文字列を除去します
+func stripQuery(rawURL string) string {
+	if i := strings.IndexByte(rawURL, '?'); i != -1 {
+		return rawURL[:i]
+	}
+	return rawURL
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestCleanContentConvertsEmbeds(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  models.Embed
+	}{
+		{
+			name:  "YouTube",
+			input: `<iframe width="560" src="https://www.youtube.com/embed/dQw4w9WgXcQ?rel=0"></iframe>`,
+			want:  models.Embed{Provider: EmbedYouTube, ID: "dQw4w9WgXcQ", URL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		},
+		{
+			name:  "YouTube（プライバシー強化モード・プロトコル相対）",
+			input: `<iframe src="//www.youtube-nocookie.com/embed/abc-123"></iframe>`,
+			want:  models.Embed{Provider: EmbedYouTube, ID: "abc-123", URL: "https://www.youtube.com/watch?v=abc-123"},
+		},
+		{
+			name: "X/Twitter（blockquote）",
+			input: `<blockquote class="twitter-tweet"><p>ポスト本文</p>&mdash; user (@user) ` +
+				`<a href="https://twitter.com/user/status/1234567890?ref_src=twsrc">2024年5月1日</a></blockquote>` +
+				`<script async src="https://platform.twitter.com/widgets.js"></script>`,
+			want: models.Embed{Provider: EmbedTwitter, ID: "1234567890", URL: "https://twitter.com/user/status/1234567890"},
+		},
+		{
+			name:  "X/Twitter（iframe）",
+			input: `<iframe src="https://platform.twitter.com/embed/Tweet.html?id=987"></iframe>`,
+			want:  models.Embed{Provider: EmbedTwitter, ID: "987", URL: "https://twitter.com/i/status/987"},
+		},
+		{
+			name: "Instagram（blockquote）",
+			input: `<blockquote class="instagram-media" data-instgrm-permalink="https://www.instagram.com/p/C1a2b3/?utm_source=ig_embed">` +
+				`<a href="https://www.instagram.com/p/C1a2b3/">投稿を見る</a></blockquote>`,
+			want: models.Embed{Provider: EmbedInstagram, ID: "C1a2b3", URL: "https://www.instagram.com/p/C1a2b3/"},
+		},
+		{
+			name:  "Spotify",
+			input: `<iframe src="https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC?utm_source=generator"></iframe>`,
+			want:  models.Embed{Provider: EmbedSpotify, ID: "4uLU6hMCjMI75M1A2tKUQC", URL: "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC"},
+		},
+		{
+			name:  "アメブロ動画",
+			input: `<iframe src="//static.blog-video.jp/?v=vh1RXRRLlBjTiNJ7IiK3tDT5"></iframe>`,
+			want:  models.Embed{Provider: EmbedAmeblo, ID: "vh1RXRRLlBjTiNJ7IiK3tDT5", URL: "https://static.blog-video.jp/?v=vh1RXRRLlBjTiNJ7IiK3tDT5"},
+		},
+	}
+
+	p := &HTMLParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := p.CleanContent(`<div><p>本文</p>` + tt.input + `</div>`)
+			if err != nil {
+				t.Fatalf("CleanContent() error = %v", err)
+			}
+			if strings.Contains(content, "<iframe") || strings.Contains(content, "<script") {
+				t.Errorf("iframe/script remains: %s", content)
+			}
+			if !strings.Contains(content, `<a href="`+tt.want.URL+`">`) {
+				t.Errorf("placeholder link not found: %s", content)
+			}
+
+			embeds := p.ExtractEmbeds(content)
+			if !reflect.DeepEqual(embeds, []models.Embed{tt.want}) {
+				t.Errorf("ExtractEmbeds() = %+v, want %+v", embeds, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanContentRemovesUnknownIframes(t *testing.T) {
+	p := &HTMLParser{}
+	content, err := p.CleanContent(`<div>本文<iframe src="https://ads.example.com/banner"></iframe></div>`)
+	if err != nil {
+		t.Fatalf("CleanContent() error = %v", err)
+	}
+	if content != `<div>本文</div>` {
+		t.Errorf("CleanContent() = %v", content)
+	}
+	if embeds := p.ExtractEmbeds(content); len(embeds) != 0 {
+		t.Errorf("ExtractEmbeds() = %+v, want none", embeds)
+	}
+}
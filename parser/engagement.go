@@ -0,0 +1,248 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// engagementCounter は反応の数を取り出すためのルールです
+type engagementCounter struct {
+	selector string         // 数が記載された要素のセレクタ
+	re       *regexp.Regexp // 要素のテキストから数を取り出す正規表現（nilの場合は最初の数値）
+	count    bool           // trueの場合は一致した要素の数を反応の数とする
+}
+
+// engagementMarkup はいいね・コメント・リブログの数のマークアップです
+type engagementMarkup struct {
+	likes    []engagementCounter
+	comments []engagementCounter
+	shares   []engagementCounter
+}
+
+// プラットフォームごとの反応の数のマークアップ
+var engagementMarkups = map[platform]engagementMarkup{
+	platformAmeblo: {
+		likes:    []engagementCounter{{selector: "[data-uranus-component='iineCount'], .skin-iineCount"}},
+		comments: []engagementCounter{{selector: "[data-uranus-component='commentCount'], .skin-commentCount"}},
+		shares:   []engagementCounter{{selector: "[data-uranus-component='reblogCount'], .skin-reblogCount"}},
+	},
+	platformLivedoor: {
+		likes: []engagementCounter{{selector: ".article-like-count, .blogreaction-count"}},
+		comments: []engagementCounter{
+			{selector: ".article-footer-comment a, .article-comment-count", re: regexp.MustCompile(`(\d[\d,]*)\s*(?:コメント|件)|コメント\s*[（(]\s*(\d[\d,]*)`)},
+		},
+	},
+	platformExcite: {
+		likes: []engagementCounter{
+			{selector: ".xbg-like-count"},
+			{selector: ".POST_TAIL", re: regexp.MustCompile(`いいね[!！]?\s*[（(]?\s*(\d[\d,]*)`)},
+		},
+		comments: []engagementCounter{{selector: ".POST_TAIL", re: regexp.MustCompile(`コメント\s*[（(]\s*(\d[\d,]*)`)}},
+		shares: []engagementCounter{
+			{selector: ".POST_TAIL", re: regexp.MustCompile(`トラックバック\s*[（(]\s*(\d[\d,]*)`)},
+			{selector: ".TRACKBACK .TRACKBACK_BODY", count: true},
+		},
+	},
+	platformHatena: {
+		likes:    []engagementCounter{{selector: ".entry-footer .hatena-star-star", count: true}},
+		comments: []engagementCounter{{selector: ".comment-box .entry-comment", count: true}},
+		shares:   []engagementCounter{{selector: ".entry-footer .hatena-bookmark-count, .entry-footer .bookmark-count"}},
+	},
+}
+
+// 数値（3桁区切りのカンマを含む）
+var engagementNumberRe = regexp.MustCompile(`\d[\d,]*`)
+
+// INIT_DATAの記事情報に含まれる反応の数のキー
+const (
+	amebloIineCountKey    = "iine_cnt"
+	amebloCommentCountKey = "comment_cnt"
+	amebloReblogCountKey  = "reblog_cnt"
+)
+
+// schema.orgのInteractionCounterの種別
+var interactionTypes = map[string]string{
+	"LikeAction":    "likes",
+	"CommentAction": "comments",
+	"ShareAction":   "shares",
+}
+
+// extractEngagement はHTMLドキュメントからいいね・コメント・リブログの数を抽出します。
+// 以下の優先順位で参照し、いずれも見つからない場合は抽出済みのコメントの件数を使用します：
+// 1. アメブロのINIT_DATA
+// 2. プラットフォーム固有のマークアップ・記事データ（QiitaのLGTMなど）
+// 3. JSON-LDのinteractionStatistic・commentCount
+// 反応の数が1つも得られない場合はnilを返します。
+func extractEngagement(page *articlePage, comments []models.Comment) *models.Engagement {
+	doc := page.doc
+	counts := make(map[string]int)
+
+	if entry, _ := amebloEntry(amebloInitData(doc)); entry != nil {
+		for key, field := range map[string]string{
+			amebloIineCountKey:    "likes",
+			amebloCommentCountKey: "comments",
+			amebloReblogCountKey:  "shares",
+		} {
+			if n, ok := jsonInt(entry[key]); ok {
+				counts[field] = n
+			}
+		}
+	}
+
+	if markup, ok := engagementMarkups[page.platform]; ok {
+		for field, counters := range map[string][]engagementCounter{
+			"likes":    markup.likes,
+			"comments": markup.comments,
+			"shares":   markup.shares,
+		} {
+			if _, found := counts[field]; found {
+				continue
+			}
+			if n, ok := countEngagement(doc, counters); ok {
+				counts[field] = n
+			}
+		}
+	}
+
+	for field, n := range page.meta.engagement {
+		if _, found := counts[field]; !found {
+			counts[field] = n
+		}
+	}
+
+	for field, n := range jsonLDEngagement(doc) {
+		if _, found := counts[field]; !found {
+			counts[field] = n
+		}
+	}
+
+	if _, found := counts["comments"]; !found && len(comments) > 0 {
+		counts["comments"] = countComments(comments)
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+	return &models.Engagement{
+		Likes:    counts["likes"],
+		Comments: counts["comments"],
+		Shares:   counts["shares"],
+	}
+}
+
+// countEngagement はルールを順に適用し、最初に得られた数を返します
+func countEngagement(doc *goquery.Document, counters []engagementCounter) (int, bool) {
+	for _, counter := range counters {
+		selection := doc.Find(counter.selector)
+		if selection.Length() == 0 {
+			continue
+		}
+		if counter.count {
+			return selection.Length(), true
+		}
+
+		found := false
+		n := 0
+		selection.EachWithBreak(func(i int, s *goquery.Selection) bool {
+			n, found = parseEngagementCount(s.Text(), counter.re)
+			return !found
+		})
+		if found {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// parseEngagementCount はテキストから反応の数を取り出します
+func parseEngagementCount(text string, re *regexp.Regexp) (int, bool) {
+	number := ""
+	if re == nil {
+		number = engagementNumberRe.FindString(text)
+	} else if m := re.FindStringSubmatch(text); m != nil {
+		// 最初に一致したサブマッチを使用する
+		for _, group := range m[1:] {
+			if group != "" {
+				number = group
+				break
+			}
+		}
+	}
+	if number == "" {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(strings.ReplaceAll(number, ",", ""))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// jsonLDEngagement はJSON-LDの記事情報から反応の数を取り出します
+func jsonLDEngagement(doc *goquery.Document) map[string]int {
+	counts := make(map[string]int)
+	article := jsonLDArticle(doc)
+	if article == nil {
+		return counts
+	}
+
+	if n, ok := jsonInt(article["commentCount"]); ok {
+		counts["comments"] = n
+	}
+
+	var stats []any
+	switch t := article["interactionStatistic"].(type) {
+	case []any:
+		stats = t
+	case map[string]any:
+		stats = []any{t}
+	}
+	for _, stat := range stats {
+		obj, ok := stat.(map[string]any)
+		if !ok {
+			continue
+		}
+		// interactionTypeは"LikeAction"、"https://schema.org/LikeAction"、{"@type": "LikeAction"}のいずれかで記述される
+		typ := jsonString(obj["interactionType"])
+		if m, ok := obj["interactionType"].(map[string]any); ok {
+			typ = jsonString(m["@type"])
+		}
+		typ = typ[strings.LastIndex(typ, "/")+1:]
+
+		field, ok := interactionTypes[typ]
+		if !ok {
+			continue
+		}
+		if n, ok := jsonInt(obj["userInteractionCount"]); ok {
+			counts[field] = n
+		}
+	}
+	return counts
+}
+
+// jsonInt はJSONの数値または数字の文字列を整数に変換します
+func jsonInt(v any) (int, bool) {
+	s := jsonNumberString(v)
+	if s == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// countComments は返信を含むコメントの件数を数えます
+func countComments(comments []models.Comment) int {
+	n := len(comments)
+	for _, c := range comments {
+		n += countComments(c.Replies)
+	}
+	return n
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestExtractEngagement(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		comments []models.Comment
+		want     *models.Engagement
+	}{
+		{
+			name: "アメブロのINIT_DATA",
+			html: `<script>window.INIT_DATA={"entryState":{"entryMap":{"123":{"entry_id":123,"blog_id":1,"iine_cnt":45,"comment_cnt":"6","reblog_cnt":2}}},"router":{"location":{"pathname":"/user/entry-123.html"}}};</script>`,
+			want: &models.Engagement{Likes: 45, Comments: 6, Shares: 2},
+		},
+		{
+			name: "livedoorのコメント数",
+			html: `<script>var ld_blog_vars = {};</script>
+<footer class="article-footer"><div class="article-footer-comment"><a href="/archives/1.html#comments">1,234コメント</a></div></footer>`,
+			want: &models.Engagement{Comments: 1234},
+		},
+		{
+			name: "エキサイトブログの記事フッター",
+			html: `<div class="POST_BODY">本文</div>
+<div class="POST_TAIL"><span class="TIME">by <span class="AUTHOR">user</span> | <a href="/1/">2011-09-12 23:31</a> | <a href="/1/#1_1">コメント(3)</a> | <a href="/tb/1">トラックバック(1)</a></span></div>`,
+			want: &models.Engagement{Comments: 3, Shares: 1},
+		},
+		{
+			name: "はてなブログのスターとコメント",
+			html: `<html data-admin-domain="//blog.hatena.ne.jp"><body>
+<footer class="entry-footer"><span class="hatena-star-star"></span><span class="hatena-star-star"></span></footer>
+<div class="comment-box"><ul><li class="entry-comment">a</li><li class="entry-comment">b</li><li class="entry-comment">c</li></ul></div>
+</body></html>`,
+			want: &models.Engagement{Likes: 2, Comments: 3},
+		},
+		{
+			name: "JSON-LDのinteractionStatistic",
+			html: `<script type="application/ld+json">{"@type":"BlogPosting","headline":"t","commentCount":4,
+"interactionStatistic":[
+  {"@type":"InteractionCounter","interactionType":"https://schema.org/LikeAction","userInteractionCount":10},
+  {"@type":"InteractionCounter","interactionType":{"@type":"ShareAction"},"userInteractionCount":"7"}
+]}</script>`,
+			want: &models.Engagement{Likes: 10, Comments: 4, Shares: 7},
+		},
+		{
+			name:     "抽出済みのコメントの件数",
+			html:     `<article>本文</article>`,
+			comments: []models.Comment{{Body: "a", Replies: []models.Comment{{Body: "b"}}}, {Body: "c"}},
+			want:     &models.Engagement{Comments: 3},
+		},
+		{
+			name: "反応の数なし",
+			html: `<article>本文</article>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := extractEngagement(newArticlePage(doc), tt.comments); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractEngagement() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractEngagementSample(t *testing.T) {
+	doc := loadSampleDocument(t, filepath.Join("..", "sample", "test", "testdata", "9994362.html"))
+	want := &models.Engagement{Comments: 0}
+	if got := extractEngagement(newArticlePage(doc), nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("extractEngagement() = %+v, want %+v", got, want)
+	}
+}
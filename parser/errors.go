@@ -11,4 +11,11 @@ var (
 	// パーサー関連のエラー
 	ErrTokenizer = errors.New("形態素解析器の初期化に失敗しました")
 	ErrParsing   = errors.New("HTMLコンテンツのパースに失敗しました")
+
+	// URL書き換え関連のエラー
+	ErrURLMapping = errors.New("URL対応表の読み込みに失敗しました")
+
+	// フィード関連のエラー
+	ErrUnsupportedFeed = errors.New("対応していないフィード形式です")
+	ErrMTFormat        = errors.New("Movable Type形式の記事が見つかりません")
 )
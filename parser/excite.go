@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// エキサイトブログのカテゴリのURL（https://example.exblog.jp/i3/）
+var exciteCategoryRe = regexp.MustCompile(`/i\d+/?$`)
+
+// exciteMeta はエキサイトブログの記事末尾（.POST_TAIL）とmetaから公開日時・カテゴリ・著者名を取り出します。
+// 記事末尾は「by ブログ名 | 2010-05-01 12:34 | カテゴリ | トラックバック(0) | コメント(2)」の形式です。
+func exciteMeta(doc *goquery.Document) articleMeta {
+	var meta articleMeta
+
+	if t, ok := extractDateFromTextInLocation(markupText(doc, ".POST_TAIL .TIME, .POST_TAIL"), jstLocation); ok {
+		meta.date = t
+	}
+
+	doc.Find(".POST_TAIL a").Each(func(i int, s *goquery.Selection) {
+		if !exciteCategoryRe.MatchString(s.AttrOr("href", "")) {
+			return
+		}
+		if category := strings.TrimSpace(s.Text()); category != "" && !containsString(meta.categories, category) {
+			meta.categories = append(meta.categories, category)
+		}
+	})
+
+	// ニックネームがない場合は記事末尾の「by」の後の名前を使用する
+	meta.author = strings.TrimSpace(doc.Find("meta[property='exblog:nickname']").First().AttrOr("content", ""))
+	if meta.author == "" {
+		meta.author = markupText(doc, ".POST_TAIL .AUTHOR")
+	}
+	return meta
+}
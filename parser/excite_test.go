@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestExciteMeta(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want articleMeta
+	}{
+		{
+			name: "ニックネームを優先",
+			html: `<meta property="exblog:nickname" content="suiu"><div class="POST_TAIL"><span class="TIME">by <span class="AUTHOR">kapparinrin</span> | <a href="https://kapparin.exblog.jp/16274503/">2011-09-12 23:31</a></span></div>`,
+			want: articleMeta{author: "suiu", date: time.Date(2011, 9, 12, 14, 31, 0, 0, time.UTC)},
+		},
+		{
+			name: "ブログのURLがiで始まる場合もカテゴリと区別する",
+			html: `<div class="POST_TAIL"><a href="https://iwate.exblog.jp/1/">2010-05-01 12:34</a> | <a href="https://iwate.exblog.jp/i12/">旅行</a></div>`,
+			want: articleMeta{date: time.Date(2010, 5, 1, 3, 34, 0, 0, time.UTC), categories: []string{"旅行"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := exciteMeta(doc)
+			if !got.date.Equal(tt.want.date) {
+				t.Errorf("date = %v, want %v", got.date, tt.want.date)
+			}
+			got.date, tt.want.date = time.Time{}, time.Time{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exciteMeta() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package parser
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+	"golang.org/x/net/html/charset"
+)
+
+// フィードの日付フォーマット（RFC 822・W3CDTF）
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC3339Nano,
+	"2006-01-02T15:04-07:00",
+}
+
+// FeedParser はRSS 2.0・RSS 1.0（RDF）・Atomのフィードからブログ記事を解析するパーサーです。
+type FeedParser struct {
+	html *HTMLParser
+}
+
+// NewFeedParser は新しいFeedParserを作成します。
+// 各記事の本文はHTMLParserと同じオプションでクリーニング・要約されます。
+func NewFeedParser(opts ...Option) *FeedParser {
+	return &FeedParser{html: newHTMLParser(opts)}
+}
+
+// Parse はio.Readerからフィードを読み込み、各記事をBlogPostに変換します。
+// 本文はcontent:encoded、Atomのcontent、description、Atomのsummaryの順に探し、
+// CleanContent・GenerateSummary・ExtractImagesと同じ処理を適用します。
+func (f *FeedParser) Parse(ctx context.Context, r io.Reader) ([]*models.BlogPost, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	doc, err := decodeFeed(r)
+	if err != nil {
+		return nil, err
+	}
+
+	items := doc.Items
+	if doc.Channel != nil {
+		items = append(items, doc.Channel.Items...)
+	}
+	items = append(items, doc.Entries...)
+
+	siteName := doc.Title
+	if doc.Channel != nil {
+		siteName = doc.Channel.Title
+	}
+	siteName = strings.TrimSpace(siteName)
+
+	posts := make([]*models.BlogPost, 0, len(items))
+	for i, item := range items {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		post, err := f.parseItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("フィードの%d件目の記事の解析に失敗しました: %w", i+1, err)
+		}
+		post.SiteName = siteName
+		if f.html.slugs != nil {
+			post.Slug = f.html.slugs.Generate(post)
+		}
+		posts = append(posts, post)
+	}
+	return posts, nil
+}
+
+// decodeFeed はフィードのXMLを読み込みます（UTF-8以外の文字コードにも対応）
+func decodeFeed(r io.Reader) (*feedDocument, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charset.NewReaderLabel
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrUnsupportedFeed
+		}
+		if err != nil {
+			return nil, fmt.Errorf("フィードのXMLのパースに失敗しました: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "rss", "RDF", "feed":
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedFeed, start.Name.Local)
+		}
+
+		var doc feedDocument
+		if err := dec.DecodeElement(&doc, &start); err != nil {
+			return nil, fmt.Errorf("フィードのXMLのパースに失敗しました: %w", err)
+		}
+		return &doc, nil
+	}
+}
+
+// parseItem はフィードの1件の記事をBlogPostに変換します
+func (f *FeedParser) parseItem(item feedItem) (*models.BlogPost, error) {
+	p := f.html
+	link := item.link()
+	post := &models.BlogPost{
+		Title:      cleanTitle(item.Title.text()),
+		Author:     item.author(),
+		Categories: item.categories(),
+		CreatedAt:  item.published(),
+		UpdatedAt:  parseFeedDate(item.Updated),
+		URL:        link,
+		Published:  true,
+	}
+
+	// 相対URLは記事のURL（なければWithBaseURLの基準URL）で解決する
+	if err := p.applyContent(post, item.content(), parseAbsoluteURL(link), platformFromURL(link)); err != nil {
+		return nil, err
+	}
+	if post.FirstImage == "" {
+		post.FirstImage = item.thumbnail()
+	}
+	return post, nil
+}
+
+// applyContent はフィード・エクスポートファイルの本文のHTMLに、HTMLの解析時と同じ
+// クリーニング・見出しの抽出・要約生成・統計情報の算出・画像抽出を適用してpostに設定します。
+// 本文が空の場合は何もしません。baseがnilの場合はWithBaseURLの基準URLで相対URLを解決します。
+// pfは本文の出力元のブログサービスで、そのサービス固有の不要な要素だけを削除します（不明な場合はplatformUnknown）。
+func (p *HTMLParser) applyContent(post *models.BlogPost, content string, base *url.URL, pf platform) error {
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	if base == nil {
+		base = parseAbsoluteURL(p.baseURL)
+	}
+
+	content, err := p.cleanContent(content, base, pf)
+	if err != nil {
+		return fmt.Errorf("コンテンツのクリーニングに失敗しました: %w", err)
+	}
+	outline, content, err := buildOutline(content, p.headingIDs)
+	if err != nil {
+		return fmt.Errorf("見出しの抽出に失敗しました: %w", err)
+	}
+	summary, err := p.GenerateSummary(content)
+	if err != nil {
+		return fmt.Errorf("サマリの生成に失敗しました: %w", err)
+	}
+	stats, err := p.ComputeStats(content)
+	if err != nil {
+		return fmt.Errorf("統計情報の算出に失敗しました: %w", err)
+	}
+
+	post.Content = content
+	post.Summary = summary
+	post.Outline = outline
+	post.Embeds = p.ExtractEmbeds(content)
+	post.Stats = stats
+	if images := p.ExtractImages(content); len(images) > 0 {
+		post.FirstImage = images[0].URL
+	}
+	return nil
+}
+
+// parseFeedDate はフィードの日付文字列をtime.Timeに変換します（失敗した場合はゼロ値）
+func parseFeedDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	if t, err := parseDateString(s); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// feedDocument はRSS 2.0（rss/channel/item）・RSS 1.0（rdf:RDF/item）・Atom（feed/entry）の共通の構造です
+type feedDocument struct {
+	Title   string       `xml:"title"`
+	Channel *feedChannel `xml:"channel"`
+	Items   []feedItem   `xml:"item"`
+	Entries []feedItem   `xml:"entry"`
+}
+
+type feedChannel struct {
+	Title string     `xml:"title"`
+	Items []feedItem `xml:"item"`
+}
+
+// feedItem はRSSのitemとAtomのentryの共通の構造です
+type feedItem struct {
+	Title       feedText        `xml:"title"`
+	Links       []feedLink      `xml:"link"`
+	GUID        string          `xml:"guid"`
+	Description string          `xml:"description"`
+	Encoded     string          `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Content     *feedText       `xml:"http://www.w3.org/2005/Atom content"`
+	Summary     *feedText       `xml:"http://www.w3.org/2005/Atom summary"`
+	PubDate     string          `xml:"pubDate"`
+	Date        string          `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Published   string          `xml:"http://www.w3.org/2005/Atom published"`
+	Updated     string          `xml:"http://www.w3.org/2005/Atom updated"`
+	Categories  []feedCategory  `xml:"category"`
+	Subjects    []string        `xml:"http://purl.org/dc/elements/1.1/ subject"`
+	Creator     string          `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Author      *feedAuthor     `xml:"author"`
+	Enclosures  []feedEnclosure `xml:"enclosure"`
+	Thumbnail   *feedEnclosure  `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// feedLink はRSSのlink（テキスト）とAtomのlink（href属性）の共通の構造です
+type feedLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// feedText はAtomのテキスト構造（content・summary）です
+type feedText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// feedCategory はRSSのcategory（テキスト）とAtomのcategory（term属性）の共通の構造です
+type feedCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+	Text  string `xml:",chardata"`
+}
+
+// feedAuthor はRSSのauthor（テキスト）とAtomのauthor（name要素）の共通の構造です
+type feedAuthor struct {
+	Name string `xml:"name"`
+	Text string `xml:",chardata"`
+}
+
+type feedEnclosure struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// link は記事のURLを返します
+func (item feedItem) link() string {
+	for _, l := range item.Links {
+		if text := strings.TrimSpace(l.Text); text != "" {
+			return text
+		}
+		if l.Href != "" && (l.Rel == "" || l.Rel == "alternate") {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	// guidがURLの場合はパーマリンクとして使用する
+	if guid := strings.TrimSpace(item.GUID); parseAbsoluteURL(guid) != nil {
+		return guid
+	}
+	return ""
+}
+
+// content は記事の本文のHTMLを返します
+func (item feedItem) content() string {
+	if strings.TrimSpace(item.Encoded) != "" {
+		return item.Encoded
+	}
+	if html := item.Content.html(); strings.TrimSpace(html) != "" {
+		return html
+	}
+	if strings.TrimSpace(item.Description) != "" {
+		return item.Description
+	}
+	return item.Summary.html()
+}
+
+// html はAtomのテキスト構造をHTMLとして返します
+func (t *feedText) html() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Type {
+	case "xhtml":
+		return t.Inner
+	case "", "text":
+		return html.EscapeString(t.Text)
+	}
+	return t.Text
+}
+
+// text はテキストを返します。エンティティはXMLのデコード時に展開済みのため、
+// HTMLとしてエスケープされたAtomのtype="html"のテキストだけを再度展開します。
+func (t feedText) text() string {
+	if t.Type == "html" {
+		return html.UnescapeString(t.Text)
+	}
+	return t.Text
+}
+
+// published は記事の公開日時を返します
+func (item feedItem) published() time.Time {
+	for _, s := range []string{item.PubDate, item.Published, item.Date, item.Updated} {
+		if t := parseFeedDate(s); !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// author は記事の著者名を返します
+func (item feedItem) author() string {
+	if creator := strings.TrimSpace(item.Creator); creator != "" {
+		return creator
+	}
+	if item.Author == nil {
+		return ""
+	}
+	if name := strings.TrimSpace(item.Author.Name); name != "" {
+		return name
+	}
+	return strings.TrimSpace(item.Author.Text)
+}
+
+// categories は記事のカテゴリを重複なく返します
+func (item feedItem) categories() []string {
+	var names []string
+	for _, c := range item.Categories {
+		names = append(names, firstNonEmptyString(c.Text, c.Label, c.Term))
+	}
+	names = append(names, item.Subjects...)
+
+	var result []string
+	for _, name := range names {
+		name = cleanCategory(name)
+		if isValidCategory(name) && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// thumbnail は本文に画像がない場合に使用する画像URLを返します
+func (item feedItem) thumbnail() string {
+	if item.Thumbnail != nil && item.Thumbnail.URL != "" {
+		return normalizeImageURL(item.Thumbnail.URL)
+	}
+	for _, e := range item.Enclosures {
+		if strings.HasPrefix(e.Type, "image/") && e.URL != "" {
+			return normalizeImageURL(e.URL)
+		}
+	}
+	return ""
+}
+
+// firstNonEmptyString は最初の空白以外を含む文字列を前後の空白を除いて返します
+func firstNonEmptyString(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
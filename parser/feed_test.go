@@ -0,0 +1,304 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+const testRSS2Feed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+<title>アルツフルデイズ</title>
+<link>https://ameblo.jp/macb2b37/</link>
+<item>
+<title>『ルーティーン』</title>
+<link>https://ameblo.jp/macb2b37/entry-12887862927.html</link>
+<description>一部の本文</description>
+<content:encoded><![CDATA[<p>今日も朝から散歩に行った。</p><script>alert(1)</script><p><img src="/user_images/a/o1.jpg"></p>]]></content:encoded>
+<pubDate>Sun, 13 Apr 2025 18:18:05 +0900</pubDate>
+<category>認知症</category>
+<category>認知症</category>
+<dc:creator>まっく</dc:creator>
+</item>
+<item>
+<title>PR: &amp;lt;b&amp;gt; 記事</title>
+<link>https://example.com/ad</link>
+<description>広告</description>
+</item>
+</channel>
+</rss>`
+
+const testRDFFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns="http://purl.org/rss/1.0/" xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel rdf:about="http://blog.livedoor.jp/example/">
+<title>livedoorのブログ</title>
+<link>http://blog.livedoor.jp/example/</link>
+</channel>
+<item rdf:about="http://blog.livedoor.jp/example/archives/1.html">
+<title>最初の記事</title>
+<link>http://blog.livedoor.jp/example/archives/1.html</link>
+<description>&lt;p&gt;本文です。&lt;/p&gt;</description>
+<dc:subject>日記</dc:subject>
+<dc:creator>管理人</dc:creator>
+<dc:date>2025-04-01T10:00:00+09:00</dc:date>
+</item>
+</rdf:RDF>`
+
+const testAtomFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+<title>はてなのブログ</title>
+<entry>
+<title>Atomの記事</title>
+<link rel="edit" href="https://example.hatenablog.com/atom/entry/1"/>
+<link rel="alternate" type="text/html" href="https://example.hatenablog.com/entry/2025/04/01/100000"/>
+<published>2025-04-01T10:00:00+09:00</published>
+<updated>2025-04-02T08:00:00+09:00</updated>
+<summary type="text">要約</summary>
+<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>XHTMLの本文。</p></div></content>
+<category term="技術" label="技術"/>
+<author><name>はてなユーザー</name></author>
+</entry>
+<entry>
+<title type="html">本文なし &amp;amp; 画像</title>
+<id>tag:example.hatenablog.com,2025:entry/2</id>
+<updated>2025-04-03T08:00:00+09:00</updated>
+<media:thumbnail url="https://cdn-ak.f.st-hatena.com/images/a.jpg"/>
+</entry>
+</feed>`
+
+func TestFeedParserParse(t *testing.T) {
+	jst := time.FixedZone("JST", 9*3600)
+
+	tests := []struct {
+		name        string
+		feed        string
+		count       int
+		title       string
+		url         string
+		author      string
+		siteName    string
+		categories  []string
+		createdAt   time.Time
+		updatedAt   time.Time
+		contains    []string
+		notContains []string
+		firstImage  string
+	}{
+		{
+			name:        "RSS 2.0",
+			feed:        testRSS2Feed,
+			count:       2,
+			title:       "『ルーティーン』",
+			url:         "https://ameblo.jp/macb2b37/entry-12887862927.html",
+			author:      "まっく",
+			siteName:    "アルツフルデイズ",
+			categories:  []string{"認知症"},
+			createdAt:   time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			contains:    []string{"今日も朝から散歩に行った。"},
+			notContains: []string{"script", "一部の本文"},
+			firstImage:  "https://ameblo.jp/user_images/a/o1.jpg",
+		},
+		{
+			name:       "RSS 1.0（RDF）",
+			feed:       testRDFFeed,
+			count:      1,
+			title:      "最初の記事",
+			url:        "http://blog.livedoor.jp/example/archives/1.html",
+			author:     "管理人",
+			siteName:   "livedoorのブログ",
+			categories: []string{"日記"},
+			createdAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, jst),
+			contains:   []string{"<p>本文です。</p>"},
+		},
+		{
+			name:       "Atom",
+			feed:       testAtomFeed,
+			count:      2,
+			title:      "Atomの記事",
+			url:        "https://example.hatenablog.com/entry/2025/04/01/100000",
+			author:     "はてなユーザー",
+			siteName:   "はてなのブログ",
+			categories: []string{"技術"},
+			createdAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, jst),
+			updatedAt:  time.Date(2025, 4, 2, 8, 0, 0, 0, jst),
+			contains:   []string{"<p>XHTMLの本文。</p>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, err := NewFeedParser().Parse(context.Background(), strings.NewReader(tt.feed))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(posts) != tt.count {
+				t.Fatalf("len(posts) = %d, want %d", len(posts), tt.count)
+			}
+			post := posts[0]
+			if post.Title != tt.title {
+				t.Errorf("Title = %q, want %q", post.Title, tt.title)
+			}
+			if post.URL != tt.url {
+				t.Errorf("URL = %q, want %q", post.URL, tt.url)
+			}
+			if post.Author != tt.author {
+				t.Errorf("Author = %q, want %q", post.Author, tt.author)
+			}
+			if !post.Published {
+				t.Error("Published = false, want true")
+			}
+			if post.SiteName != tt.siteName {
+				t.Errorf("SiteName = %q, want %q", post.SiteName, tt.siteName)
+			}
+			if !reflect.DeepEqual(post.Categories, tt.categories) {
+				t.Errorf("Categories = %v, want %v", post.Categories, tt.categories)
+			}
+			if !post.CreatedAt.Equal(tt.createdAt) {
+				t.Errorf("CreatedAt = %v, want %v", post.CreatedAt, tt.createdAt)
+			}
+			if !post.UpdatedAt.Equal(tt.updatedAt) {
+				t.Errorf("UpdatedAt = %v, want %v", post.UpdatedAt, tt.updatedAt)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(post.Content, want) {
+					t.Errorf("Content does not contain %q: %s", want, post.Content)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(post.Content, unwanted) {
+					t.Errorf("Content contains %q: %s", unwanted, post.Content)
+				}
+			}
+			if post.Summary == "" {
+				t.Error("Summary is empty")
+			}
+			if post.Stats.Characters == 0 {
+				t.Error("Stats.Characters is 0")
+			}
+			if post.FirstImage != tt.firstImage {
+				t.Errorf("FirstImage = %q, want %q", post.FirstImage, tt.firstImage)
+			}
+		})
+	}
+}
+
+func TestFeedParserParseWithoutContent(t *testing.T) {
+	posts, err := NewFeedParser().Parse(context.Background(), strings.NewReader(testAtomFeed))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	post := posts[1]
+	if post.Title != "本文なし & 画像" {
+		t.Errorf("Title = %q", post.Title)
+	}
+	if post.Content != "" || post.Summary != "" {
+		t.Errorf("Content = %q, Summary = %q, want empty", post.Content, post.Summary)
+	}
+	if post.FirstImage != "https://cdn-ak.f.st-hatena.com/images/a.jpg" {
+		t.Errorf("FirstImage = %q", post.FirstImage)
+	}
+	// 公開日時がない場合は更新日時を使用する
+	if want := time.Date(2025, 4, 3, 8, 0, 0, 0, time.FixedZone("", 9*3600)); !post.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", post.CreatedAt, want)
+	}
+}
+
+func TestFeedParserParseTitle(t *testing.T) {
+	posts, err := NewFeedParser().Parse(context.Background(), strings.NewReader(testRSS2Feed))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// 「PR:」で始まる記事も除外せず、XMLのデコード後のタイトルを再度展開しない
+	if len(posts) != 2 {
+		t.Fatalf("len(posts) = %d, want 2", len(posts))
+	}
+	if want := "PR: &lt;b&gt; 記事"; posts[1].Title != want {
+		t.Errorf("Title = %q, want %q", posts[1].Title, want)
+	}
+}
+
+func TestFeedParserParseShiftJIS(t *testing.T) {
+	feed := strings.Replace(testRSS2Feed, `encoding="UTF-8"`, `encoding="Shift_JIS"`, 1)
+	encoded, err := japanese.ShiftJIS.NewEncoder().String(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	posts, err := NewFeedParser().Parse(context.Background(), bytes.NewReader([]byte(encoded)))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(posts) != 2 || posts[0].Title != "『ルーティーン』" {
+		t.Errorf("posts = %+v", posts)
+	}
+}
+
+func TestFeedParserParseWithSlugStrategy(t *testing.T) {
+	posts, err := NewFeedParser(WithSlugStrategy(SlugDateID)).Parse(context.Background(), strings.NewReader(testRSS2Feed))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if posts[0].Slug == "" {
+		t.Error("Slug is empty")
+	}
+}
+
+func TestFeedParserParseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		feed        string
+		unsupported bool
+	}{
+		{"HTML", "<html><body>本文</body></html>", true},
+		{"空", "", true},
+		{"壊れたXML", "<rss><channel><item><title>a</item>", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFeedParser().Parse(context.Background(), strings.NewReader(tt.feed))
+			if err == nil {
+				t.Fatal("Parse() error = nil")
+			}
+			if got := errors.Is(err, ErrUnsupportedFeed); got != tt.unsupported {
+				t.Errorf("errors.Is(err, ErrUnsupportedFeed) = %v, want %v (err = %v)", got, tt.unsupported, err)
+			}
+		})
+	}
+}
+
+func TestFeedParserParseWithCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewFeedParser().Parse(ctx, strings.NewReader(testRSS2Feed)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Parse() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestParseFeedDate(t *testing.T) {
+	jst := time.FixedZone("JST", 9*3600)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"Sun, 13 Apr 2025 18:18:05 +0900", time.Date(2025, 4, 13, 18, 18, 5, 0, jst)},
+		{"Sun, 6 Apr 2025 18:18:05 +0900", time.Date(2025, 4, 6, 18, 18, 5, 0, jst)},
+		{"2025-04-01T10:00:00+09:00", time.Date(2025, 4, 1, 10, 0, 0, 0, jst)},
+		{"2025-04-01T10:00+09:00", time.Date(2025, 4, 1, 10, 0, 0, 0, jst)},
+		{"2025-04-01", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"不明", time.Time{}},
+		{"", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := parseFeedDate(tt.in); !got.Equal(tt.want) {
+				t.Errorf("parseFeedDate(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
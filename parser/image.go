@@ -17,6 +17,7 @@ type ImageInfo struct {
 }
 
 // ExtractImages はHTML内のすべての画像情報を抽出します
+// 相対URLはドキュメントの基準URL（<base href>、canonical、og:url、WithBaseURL）で解決します
 func (p *HTMLParser) ExtractImages(content string) []ImageInfo {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
 	if err != nil {
@@ -25,12 +26,15 @@ func (p *HTMLParser) ExtractImages(content string) []ImageInfo {
 
 	var images []ImageInfo
 
+	// 相対URLを解決するための基準URL
+	base := documentBaseURL(doc, p.baseURL)
+
 	// 1. まずOGP画像を探す
 	ogImage, _ := doc.Find("meta[property='og:image']").Attr("content")
 	if ogImage != "" {
 		// OGP画像の情報を収集
 		ogImageInfo := ImageInfo{
-			URL:         normalizeImageURL(ogImage),
+			URL:         normalizeImageURL(resolveURL(base, ogImage)),
 			Alt:         "OGP Image",
 			Description: doc.Find("meta[property='og:description']").AttrOr("content", ""),
 		}
@@ -44,7 +48,7 @@ func (p *HTMLParser) ExtractImages(content string) []ImageInfo {
 		twitterImage, _ := doc.Find("meta[name='twitter:image']").Attr("content")
 		if twitterImage != "" {
 			twitterImageInfo := ImageInfo{
-				URL:         normalizeImageURL(twitterImage),
+				URL:         normalizeImageURL(resolveURL(base, twitterImage)),
 				Alt:         "Twitter Card Image",
 				Description: doc.Find("meta[name='twitter:description']").AttrOr("content", ""),
 			}
@@ -62,8 +66,8 @@ func (p *HTMLParser) ExtractImages(content string) []ImageInfo {
 			imgURL, _ = s.Attr("src")
 		}
 
-		// 画像URLの正規化
-		imgURL = normalizeImageURL(imgURL)
+		// 画像URLの正規化（相対URLは絶対URLに変換）
+		imgURL = normalizeImageURL(resolveURL(base, imgURL))
 		if imgURL == "" {
 			return
 		}
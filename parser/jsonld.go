@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// JSON-LDで記事を表す@typeの一覧
+var jsonLDArticleTypes = []string{
+	"BlogPosting",
+	"Article",
+	"NewsArticle",
+	"TechArticle",
+	"SocialMediaPosting",
+	"WebPage",
+}
+
+// jsonLDObjects はscript[type="application/ld+json"]内のJSONオブジェクトをすべて返します。
+// 配列や@graphにまとめられたオブジェクトも展開します。
+func jsonLDObjects(doc *goquery.Document) []map[string]any {
+	var objects []map[string]any
+	doc.Find("script[type='application/ld+json']").Each(func(i int, s *goquery.Selection) {
+		var v any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(s.Text())), &v); err != nil {
+			return
+		}
+		objects = append(objects, flattenJSONLD(v)...)
+	})
+	return objects
+}
+
+// flattenJSONLD は配列や@graphを展開してオブジェクトの一覧にします
+func flattenJSONLD(v any) []map[string]any {
+	var objects []map[string]any
+	switch t := v.(type) {
+	case []any:
+		for _, item := range t {
+			objects = append(objects, flattenJSONLD(item)...)
+		}
+	case map[string]any:
+		if graph, ok := t["@graph"]; ok {
+			objects = append(objects, flattenJSONLD(graph)...)
+		} else {
+			objects = append(objects, t)
+		}
+	}
+	return objects
+}
+
+// jsonLDArticle はJSON-LDから記事を表すオブジェクトを返します。
+// jsonLDArticleTypesの順に優先して探します。
+func jsonLDArticle(doc *goquery.Document) map[string]any {
+	objects := jsonLDObjects(doc)
+	for _, typ := range jsonLDArticleTypes {
+		for _, obj := range objects {
+			if jsonLDHasType(obj, typ) {
+				return obj
+			}
+		}
+	}
+	return nil
+}
+
+// jsonLDHasType はオブジェクトの@typeに指定した型が含まれるかを判定します
+func jsonLDHasType(obj map[string]any, typ string) bool {
+	switch t := obj["@type"].(type) {
+	case string:
+		return t == typ
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s == typ {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonString はJSON値から文字列を取り出します。
+// オブジェクトの場合は@id、url、nameの順に参照し、配列の場合は最初の要素を使用します。
+func jsonString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case map[string]any:
+		for _, key := range []string{"@id", "url", "name"} {
+			if s := jsonString(t[key]); s != "" {
+				return s
+			}
+		}
+	case []any:
+		if len(t) > 0 {
+			return jsonString(t[0])
+		}
+	}
+	return ""
+}
+
+// jsonObject はネストしたJSONオブジェクトをキーの順にたどって返します
+func jsonObject(v any, keys ...string) map[string]any {
+	for _, key := range keys {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// jsonFirstString はオブジェクトのキーを順に参照し、最初に得られた文字列を返します
+func jsonFirstString(obj map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s := jsonString(obj[key]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// findJSONObject はJSON値を深さ優先でたどり、条件を満たす最初のオブジェクトを返します。
+// 結果が実行ごとに変わらないように、オブジェクトのキーは辞書順にたどります。
+func findJSONObject(v any, match func(map[string]any) bool) map[string]any {
+	switch t := v.(type) {
+	case map[string]any:
+		if match(t) {
+			return t
+		}
+		for _, key := range slices.Sorted(maps.Keys(t)) {
+			if found := findJSONObject(t[key], match); found != nil {
+				return found
+			}
+		}
+	case []any:
+		for _, child := range t {
+			if found := findJSONObject(child, match); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"encoding/json"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Mediumのページに埋め込まれたApolloの初期データの変数名
+const mediumApolloPrefix = "window.__APOLLO_STATE__"
+
+// 拍手の数の表記（1.2K、3M）
+var mediumCountRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([KkMm])?`)
+
+// 記事のURLの末尾の記事ID（/understanding-go-interfaces-1a2b3c4d5e6f、/p/1a2b3c4d5e6f）
+var mediumPostIDRe = regexp.MustCompile(`[-/]([0-9a-f]{8,12})/?(?:[?#].*)?$`)
+
+// mediumMeta はMediumの__APOLLO_STATE__から記事データを取り出します。
+// 初期データには記事・著者・パブリケーション・タグが別々のオブジェクトとして格納され、{"__ref": "User:1"}の形式で参照されます。
+func mediumMeta(doc *goquery.Document) articleMeta {
+	var meta articleMeta
+	refs, _ := scriptJSONValue(doc, mediumApolloPrefix).(map[string]any)
+	post := mediumPost(doc, refs)
+	if post == nil {
+		return mediumCountMeta(doc, meta)
+	}
+
+	meta.postID = jsonString(post["id"])
+	meta.title = jsonString(post["title"])
+	meta.subtitle = jsonString(jsonObject(post, "previewContent")["subtitle"])
+	// 公開日時はUNIX時間（ミリ秒）で格納されている
+	if ms, ok := jsonInt(post["firstPublishedAt"]); ok && ms > 0 {
+		meta.date = time.UnixMilli(int64(ms)).UTC()
+	}
+	if ms, ok := jsonInt(post["latestPublishedAt"]); ok && ms > 0 {
+		meta.updated = time.UnixMilli(int64(ms)).UTC()
+	}
+	meta.author = jsonString(apolloRef(refs, post["creator"])["name"])
+	meta.siteName = jsonString(apolloRef(refs, post["collection"])["name"])
+	if tags, ok := post["tags"].([]any); ok {
+		for _, tag := range tags {
+			obj := apolloRef(refs, tag)
+			if name := jsonFirstString(obj, "displayTitle", "normalizedTagSlug"); name != "" && !containsString(meta.tags, name) {
+				meta.tags = append(meta.tags, name)
+			}
+		}
+	}
+
+	meta.engagement = make(map[string]int)
+	if n, ok := jsonInt(post["clapCount"]); ok {
+		meta.engagement["likes"] = n
+	}
+	if n, ok := jsonInt(jsonObject(post, "postResponses")["count"]); ok {
+		meta.engagement["comments"] = n
+	}
+	return mediumCountMeta(doc, meta)
+}
+
+// mediumPost は初期データからページの記事のオブジェクトを返します。
+// 初期データにはおすすめ・関連記事のPostも含まれるため、URLの末尾の記事ID、ROOT_QUERYのpostResultの参照の順に記事を特定します。
+func mediumPost(doc *goquery.Document, refs map[string]any) map[string]any {
+	isPost := func(obj map[string]any) bool {
+		return jsonString(obj["__typename"]) == "Post" && jsonString(obj["title"]) != ""
+	}
+	if matches := mediumPostIDRe.FindStringSubmatch(extractCanonicalURL(doc)); matches != nil {
+		if post := apolloRef(refs, map[string]any{"__ref": "Post:" + matches[1]}); isPost(post) {
+			return post
+		}
+	}
+	root, _ := refs["ROOT_QUERY"].(map[string]any)
+	for _, key := range slices.Sorted(maps.Keys(root)) {
+		if !strings.HasPrefix(key, "postResult(") {
+			continue
+		}
+		if post := apolloRef(refs, root[key]); isPost(post) {
+			return post
+		}
+	}
+	// 記事を特定できない場合は、Postが1件だけのときに限りその記事とする
+	var post map[string]any
+	for _, v := range refs {
+		if obj, ok := v.(map[string]any); ok && isPost(obj) {
+			if post != nil {
+				return nil
+			}
+			post = obj
+		}
+	}
+	return post
+}
+
+// mediumCountMeta は初期データに拍手の数がない場合に、ページ上の「1.2K」のような表記から拍手の数を取り出します
+func mediumCountMeta(doc *goquery.Document, meta articleMeta) articleMeta {
+	if _, found := meta.engagement["likes"]; found {
+		return meta
+	}
+	if n, ok := parseAbbreviatedCount(doc.Find(".pw-multi-vote-count").First().Text()); ok {
+		if meta.engagement == nil {
+			meta.engagement = make(map[string]int)
+		}
+		meta.engagement["likes"] = n
+	}
+	return meta
+}
+
+// apolloRef は{"__ref": "User:1"}の形式の参照を初期データのオブジェクトに置き換えます（参照でない場合はそのまま返す）
+func apolloRef(refs map[string]any, v any) map[string]any {
+	obj, _ := v.(map[string]any)
+	if ref := jsonString(obj["__ref"]); ref != "" {
+		obj, _ = refs[ref].(map[string]any)
+	}
+	return obj
+}
+
+// parseAbbreviatedCount は「1.2K」「3M」のように省略された数を整数に変換します
+func parseAbbreviatedCount(text string) (int, bool) {
+	matches := mediumCountRe.FindStringSubmatch(strings.ReplaceAll(text, ",", ""))
+	if matches == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	switch strings.ToUpper(matches[2]) {
+	case "K":
+		n *= 1e3
+	case "M":
+		n *= 1e6
+	}
+	return int(n + 0.5), true
+}
+
+// scriptJSONValue は「window.__APOLLO_STATE__ = {...}」「window._preloads = JSON.parse("...")」のように
+// scriptで変数に代入されたJSONの値を返します（見つからない場合はnil）
+func scriptJSONValue(doc *goquery.Document, prefix string) any {
+	var value any
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		script := s.Text()
+		idx := strings.Index(script, prefix)
+		if idx == -1 {
+			return true
+		}
+		rest := strings.TrimSpace(script[idx+len(prefix):])
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "="))
+		rest = strings.TrimPrefix(rest, "JSON.parse(")
+
+		// json.Decoderは最初のJSONの値だけを読み込むため、後続のJavaScriptは無視される
+		if err := json.NewDecoder(strings.NewReader(rest)).Decode(&value); err != nil {
+			value = nil
+			return true
+		}
+		// JSON.parseに渡された文字列はもう一度JSONとして読み込む
+		if text, ok := value.(string); ok {
+			value = nil
+			if err := json.Unmarshal([]byte(text), &value); err != nil {
+				value = nil
+				return true
+			}
+		}
+		return false
+	})
+	return value
+}
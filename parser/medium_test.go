@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestParseAbbreviatedCount(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   int
+		wantOK bool
+	}{
+		{"42", 42, true},
+		{"1,234", 1234, true},
+		{"1.2K", 1200, true},
+		{"3M", 3000000, true},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseAbbreviatedCount(tt.text)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseAbbreviatedCount(%q) = (%d, %v), want (%d, %v)", tt.text, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestMediumPost(t *testing.T) {
+	const state = `"Post:0f9e8d7c6b5a":{"__typename":"Post","id":"0f9e8d7c6b5a","title":"関連記事"},` +
+		`"Post:1a2b3c4d5e6f":{"__typename":"Post","id":"1a2b3c4d5e6f","title":"記事"}`
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "URLの記事ID",
+			html: `<link rel="canonical" href="https://medium.com/@mac/title-1a2b3c4d5e6f"><script>window.__APOLLO_STATE__ = {` + state + `}</script>`,
+			want: "1a2b3c4d5e6f",
+		},
+		{
+			name: "ROOT_QUERYの参照",
+			html: `<script>window.__APOLLO_STATE__ = {"ROOT_QUERY":{"postResult({\"id\":\"1a2b3c4d5e6f\"})":{"__ref":"Post:1a2b3c4d5e6f"}},` + state + `}</script>`,
+			want: "1a2b3c4d5e6f",
+		},
+		{
+			name: "Postが1件だけ",
+			html: `<script>window.__APOLLO_STATE__ = {"Post:0f9e8d7c6b5a":{"__typename":"Post","id":"0f9e8d7c6b5a","title":"記事"}}</script>`,
+			want: "0f9e8d7c6b5a",
+		},
+		{
+			name: "記事を特定できない",
+			html: `<script>window.__APOLLO_STATE__ = {` + state + `}</script>`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			refs, _ := scriptJSONValue(doc, mediumApolloPrefix).(map[string]any)
+			if got := jsonString(mediumPost(doc, refs)["id"]); got != tt.want {
+				t.Errorf("mediumPost() id = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
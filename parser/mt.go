@@ -0,0 +1,280 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// Movable Type形式の区切り行
+const (
+	mtEntrySeparator   = "--------" // 記事の区切り
+	mtSectionSeparator = "-----"    // 記事内の項目（BODY・COMMENTなど）の区切り
+)
+
+// Movable Type形式の日時フォーマット（タイムゾーンの指定はない）
+var mtDateLayouts = []string{
+	"01/02/2006 03:04:05 PM",
+	"01/02/2006 15:04:05",
+	"01/02/2006 03:04 PM",
+	"01/02/2006 15:04",
+}
+
+// コメント・トラックバックの項目の見出し
+var mtCommentKeys = map[string]bool{
+	"AUTHOR": true, "EMAIL": true, "IP": true, "URL": true, "DATE": true,
+	"TITLE": true, "BLOG NAME": true,
+}
+
+// 判定対象の日本語の文字コード（UTF-8でない場合に使用する）
+var mtEncodings = []encoding.Encoding{japanese.ShiftJIS, japanese.EUCJP}
+
+// MTParser はMovable Type・TypePad形式のエクスポートファイルからブログ記事を解析するパーサーです。
+// livedoor・Seesaa・FC2・JUGEM・はてななどのバックアップに使用されています。
+type MTParser struct {
+	html *HTMLParser
+}
+
+// NewMTParser は新しいMTParserを作成します。
+// 各記事の本文はHTMLParserと同じオプションでクリーニング・要約されます。
+func NewMTParser(opts ...Option) *MTParser {
+	return &MTParser{html: newHTMLParser(opts)}
+}
+
+// Parse はio.ReaderからMovable Type形式のデータを読み込み、各記事をBlogPostに変換します。
+// 文字コードはUTF-8・Shift_JIS・EUC-JPを自動判定し、日時は日本時間として扱います。
+// BODYとEXTENDED BODYを連結して本文とし、CONVERT BREAKSが0以外の場合は改行を段落・brに変換します。
+func (m *MTParser) Parse(ctx context.Context, r io.Reader) ([]*models.BlogPost, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Movable Type形式のデータの読み込みに失敗しました: %w", err)
+	}
+	text, err := decodeJapaneseText(data)
+	if err != nil {
+		return nil, fmt.Errorf("Movable Type形式のデータの文字コードの変換に失敗しました: %w", err)
+	}
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+
+	var posts []*models.BlogPost
+	for i, entry := range splitMTLines(text, mtEntrySeparator) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		post, err := m.parseEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%d件目の記事の解析に失敗しました: %w", i+1, err)
+		}
+		if post == nil {
+			continue
+		}
+		if m.html.slugs != nil {
+			post.Slug = m.html.slugs.Generate(post)
+		}
+		posts = append(posts, post)
+	}
+
+	if len(posts) == 0 {
+		return nil, ErrMTFormat
+	}
+	return posts, nil
+}
+
+// parseEntry は1件の記事のテキストをBlogPostに変換します（記事の形式でない場合はnil）
+func (m *MTParser) parseEntry(entry string) (*models.BlogPost, error) {
+	sections := splitMTLines(entry, mtSectionSeparator)
+
+	header := parseMTFields(sections[0])
+	if header.first("TITLE") == "" && header.first("DATE") == "" {
+		return nil, nil
+	}
+
+	status := header.first("STATUS")
+	post := &models.BlogPost{
+		Title:     cleanTitle(header.first("TITLE")),
+		Author:    header.first("AUTHOR"),
+		CreatedAt: parseMTDate(header.first("DATE")),
+		Published: status == "" || strings.EqualFold(status, "Publish"),
+		Slug:      header.first("BASENAME"),
+		Tags:      parseMTTags(header.first("TAGS")),
+	}
+	for _, name := range append(header["PRIMARY CATEGORY"], header["CATEGORY"]...) {
+		if name = cleanCategory(name); isValidCategory(name) && !containsString(post.Categories, name) {
+			post.Categories = append(post.Categories, name)
+		}
+	}
+
+	// 改行の変換（CONVERT BREAKS）はWordPressのwpautopと同等の処理を行う
+	convert := wpautop
+	if header.first("CONVERT BREAKS") == "0" {
+		convert = func(s string) string { return strings.TrimSpace(s) }
+	}
+
+	var body []string
+	for _, section := range sections[1:] {
+		name, value := splitMTSection(section)
+		switch name {
+		case "BODY", "EXTENDED BODY":
+			if converted := convert(value); converted != "" {
+				body = append(body, converted)
+			}
+		case "COMMENT":
+			if comment := parseMTComment(value); comment.Body != "" {
+				post.Comments = append(post.Comments, comment)
+			}
+		}
+	}
+
+	if err := m.html.applyContent(post, strings.Join(body, "\n"), nil, platformUnknown); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// mtFields は「KEY: value」形式の項目です（CATEGORYなど同じキーが複数回現れる）
+type mtFields map[string][]string
+
+// first はキーの最初の値を返します
+func (f mtFields) first(key string) string {
+	if values := f[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// parseMTFields は「KEY: value」形式の行を読み込みます
+func parseMTFields(text string) mtFields {
+	fields := make(mtFields)
+	for _, line := range strings.Split(text, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		fields[key] = append(fields[key], strings.TrimSpace(value))
+	}
+	return fields
+}
+
+// splitMTLines はテキストを区切り行（前後の空白を除いて一致する行）で分割します
+func splitMTLines(text, separator string) []string {
+	var parts []string
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == separator {
+			parts = append(parts, strings.Join(current, "\n"))
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	return append(parts, strings.Join(current, "\n"))
+}
+
+// splitMTSection は「BODY:」などの見出しで始まる項目を見出しと内容に分けます
+func splitMTSection(section string) (string, string) {
+	section = strings.TrimLeft(section, "\n")
+	first, rest, _ := strings.Cut(section, "\n")
+	name, _, ok := strings.Cut(first, ":")
+	if !ok {
+		return "", section
+	}
+	return strings.ToUpper(strings.TrimSpace(name)), rest
+}
+
+// parseMTComment はCOMMENTの項目をコメントに変換します
+func parseMTComment(text string) models.Comment {
+	var comment models.Comment
+	lines := strings.Split(text, "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		key, value, ok := strings.Cut(lines[i], ":")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if !ok || !mtCommentKeys[key] {
+			break
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "AUTHOR":
+			comment.Author = value
+		case "URL":
+			comment.AuthorURL = value
+		case "DATE":
+			comment.CreatedAt = parseMTDate(value)
+		}
+	}
+	comment.Body = htmlCommentBody(strings.Join(lines[i:], "\n"))
+	return comment
+}
+
+// parseMTDate は「MM/DD/YYYY hh:mm:ss AM」形式の日時を日本時間として変換します（失敗した場合はゼロ値）
+func parseMTDate(s string) time.Time {
+	for _, layout := range mtDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, jstLocation); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseMTTags はカンマ区切り（空白を含むタグは二重引用符で囲む）のタグを分割します
+func parseMTTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	reader := csv.NewReader(strings.NewReader(s))
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	record, err := reader.Read()
+	if err != nil {
+		record = strings.Split(s, ",")
+	}
+
+	var tags []string
+	for _, tag := range record {
+		if tag = strings.TrimSpace(tag); tag != "" && !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// decodeJapaneseText はUTF-8・Shift_JIS・EUC-JPのいずれかのテキストをUTF-8の文字列に変換します。
+// UTF-8として正しくない場合は、変換できない文字が最も少ない文字コードを採用します。
+func decodeJapaneseText(data []byte) (string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return string(data), nil
+	}
+
+	best, bestInvalid := "", -1
+	for _, enc := range mtEncodings {
+		decoded, err := enc.NewDecoder().Bytes(data)
+		if err != nil {
+			continue
+		}
+		invalid := strings.Count(string(decoded), string(utf8.RuneError))
+		if bestInvalid < 0 || invalid < bestInvalid {
+			best, bestInvalid = string(decoded), invalid
+		}
+	}
+	if bestInvalid < 0 {
+		return "", ErrMTFormat
+	}
+	return best, nil
+}
@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+	"golang.org/x/text/encoding/japanese"
+)
+
+const testMTExport = `AUTHOR: まっく
+TITLE: 『ルーティーン』
+BASENAME: routine
+STATUS: Publish
+ALLOW COMMENTS: 1
+CONVERT BREAKS: 1
+DATE: 04/13/2025 06:18:05 PM
+PRIMARY CATEGORY: ブログ
+CATEGORY: 日記
+CATEGORY: ブログ
+TAGS: 認知症,"Care Log",認知症
+-----
+BODY:
+今日も朝から散歩に行った。
+帰りにパン屋に寄った。
+
+<h2>午後</h2>
+-----
+EXTENDED BODY:
+午後は昼寝をした。
+-----
+EXCERPT:
+抜粋
+-----
+KEYWORDS:
+
+-----
+COMMENT:
+AUTHOR: 読者
+EMAIL: reader@example.com
+IP: 192.0.2.1
+URL: https://reader.example.com/
+DATE: 04/14/2025 09:00:00 AM
+こんにちは<br />
+応援しています
+-----
+PING:
+TITLE: 他のブログ
+URL: https://other.example.com/
+IP: 192.0.2.2
+BLOG NAME: 他のブログ
+DATE: 04/15/2025 10:00:00 AM
+トラックバック
+-----
+--------
+AUTHOR: まっく
+TITLE: 下書き
+STATUS: Draft
+CONVERT BREAKS: 0
+DATE: 12/31/2024 23:59:00
+-----
+BODY:
+<p>書きかけの
+記事です。</p>
+-----
+--------
+`
+
+func TestMTParserParse(t *testing.T) {
+	posts, err := NewMTParser().Parse(context.Background(), strings.NewReader(testMTExport))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("len(posts) = %d, want 2", len(posts))
+	}
+
+	post := posts[0]
+	checks := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"Title", post.Title, "『ルーティーン』"},
+		{"Author", post.Author, "まっく"},
+		{"Slug", post.Slug, "routine"},
+		{"Published", post.Published, true},
+		{"CreatedAt", post.CreatedAt, time.Date(2025, 4, 13, 18, 18, 5, 0, jstLocation)},
+		{"Categories", post.Categories, []string{"ブログ", "日記"}},
+		{"Tags", post.Tags, []string{"認知症", "Care Log"}},
+		{"Comments", post.Comments, []models.Comment{
+			{
+				Author:    "読者",
+				AuthorURL: "https://reader.example.com/",
+				Body:      "こんにちは\n応援しています",
+				CreatedAt: time.Date(2025, 4, 14, 9, 0, 0, 0, jstLocation),
+			},
+		}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s = %#v, want %#v", c.name, c.got, c.want)
+		}
+	}
+	for _, want := range []string{"<p>今日も朝から散歩に行った。<br/>\n帰りにパン屋に寄った。</p>", "<h2>午後</h2>", "<p>午後は昼寝をした。</p>"} {
+		if !strings.Contains(post.Content, want) {
+			t.Errorf("Content does not contain %q: %s", want, post.Content)
+		}
+	}
+	if strings.Contains(post.Content, "抜粋") {
+		t.Errorf("Content contains EXCERPT: %s", post.Content)
+	}
+	if post.Summary == "" {
+		t.Error("Summary is empty")
+	}
+
+	draft := posts[1]
+	if draft.Published {
+		t.Error("draft Published = true")
+	}
+	if want := time.Date(2024, 12, 31, 23, 59, 0, 0, jstLocation); !draft.CreatedAt.Equal(want) {
+		t.Errorf("draft CreatedAt = %v, want %v", draft.CreatedAt, want)
+	}
+	// CONVERT BREAKS: 0 の場合は改行を変換しない
+	if !strings.Contains(draft.Content, "<p>書きかけの\n記事です。</p>") {
+		t.Errorf("draft Content = %q", draft.Content)
+	}
+}
+
+func TestMTParserParseEncodings(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func(string) (string, error)
+	}{
+		{"Shift_JIS", japanese.ShiftJIS.NewEncoder().String},
+		{"EUC-JP", japanese.EUCJP.NewEncoder().String},
+		{"UTF-8 BOM付き", func(s string) (string, error) { return "\ufeff" + s, nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.encode(testMTExport)
+			if err != nil {
+				t.Fatal(err)
+			}
+			posts, err := NewMTParser().Parse(context.Background(), bytes.NewReader([]byte(encoded)))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if posts[0].Title != "『ルーティーン』" || posts[0].Author != "まっく" {
+				t.Errorf("Title = %q, Author = %q", posts[0].Title, posts[0].Author)
+			}
+		})
+	}
+}
+
+func TestMTParserParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"空", ""},
+		{"MT形式でない", "<html><body>本文</body></html>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMTParser().Parse(context.Background(), strings.NewReader(tt.input)); !errors.Is(err, ErrMTFormat) {
+				t.Errorf("Parse() error = %v, want %v", err, ErrMTFormat)
+			}
+		})
+	}
+}
+
+func TestParseMTDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"04/13/2025 06:18:05 PM", time.Date(2025, 4, 13, 18, 18, 5, 0, jstLocation)},
+		{"04/13/2025 12:00:00 AM", time.Date(2025, 4, 13, 0, 0, 0, 0, jstLocation)},
+		{"04/13/2025 18:18:05", time.Date(2025, 4, 13, 18, 18, 5, 0, jstLocation)},
+		{"04/13/2025 06:18 PM", time.Date(2025, 4, 13, 18, 18, 0, 0, jstLocation)},
+		{"2025-04-13", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := parseMTDate(tt.in); !got.Equal(tt.want) {
+				t.Errorf("parseMTDate(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMTTags(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"a,b", []string{"a", "b"}},
+		{`"Movable Type", blog ,a`, []string{"Movable Type", "blog", "a"}},
+		{`壊れた"引用,b`, []string{`壊れた"引用`, "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := parseMTTags(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMTTags(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// navigationMarkup は前後の記事へのリンクと関連記事のマークアップを表すセレクタの組です
+type navigationMarkup struct {
+	prev    string // 前の（古い）記事へのリンク
+	next    string // 次の（新しい）記事へのリンク
+	related string // 関連記事へのリンク
+	remove  string // 本文から削除するナビゲーション・関連記事のブロック
+}
+
+// プラットフォームごとのナビゲーションのマークアップ。
+// アメブロ・エキサイトブログ・Blogger・はてなブログは左側（«・前へ）のリンクが新しい記事のため、前後を入れ替えて指定する。
+var navigationMarkups = map[platform]navigationMarkup{
+	platformAmeblo: {
+		prev:    "a.pagingNext",
+		next:    "a.pagingPrev",
+		related: "[data-uranus-component='relatedEntryList'] a[href*='/entry-'], .skin-relatedEntry a[href*='/entry-']",
+		remove:  ".pagingArea, [data-uranus-component='relatedEntryList'], .skin-relatedEntry",
+	},
+	platformLivedoor: {
+		prev:    ".article-pager li.prev a, .article-pager-prev a",
+		next:    ".article-pager li.next a, .article-pager-next a",
+		related: ".related-articles li a, .article-relation li a",
+		remove:  ".article-pager, .pager, .related-articles, .article-relation, .article-sub-category",
+	},
+	platformExcite: {
+		prev:    ".pageTool .next a, a.older_page",
+		next:    ".pageTool .pre a, a.newer_page",
+		related: ".relatedPosts a, .related_post a",
+		remove:  ".pageTool, .relatedPosts, .related_post",
+	},
+	platformBlogger: {
+		prev:    "#blog-pager-older-link a, a.blog-pager-older-link",
+		next:    "#blog-pager-newer-link a, a.blog-pager-newer-link",
+		related: ".related-posts a, .related-post a",
+		remove:  ".blog-pager, #blog-pager, .related-posts",
+	},
+	platformHatena: {
+		prev:    ".pager-next a",
+		next:    ".pager-prev a",
+		related: ".related-entries a.urllist-title-link",
+		remove:  ".pager, .hatena-module-related-entries, .related-entries",
+	},
+}
+
+// プラットフォームを判定できない場合のナビゲーションのマークアップ
+var genericNavigationMarkup = navigationMarkup{
+	prev:    "a[rel='prev'], .nav-previous a, .post-navigation .previous a, .prev-entry a",
+	next:    "a[rel='next'], .nav-next a, .post-navigation .next a, .next-entry a",
+	related: "#jp-relatedposts a.jp-relatedposts-post-a, .yarpp-related a, .related-posts a",
+	remove:  ".post-navigation, .navigation.post-navigation, .nav-links, #jp-relatedposts, .yarpp-related, .related-posts",
+}
+
+// ナビゲーションのリンクテキストに含まれる定型の表記
+var navigationLabelRe = regexp.MustCompile(`^(?:[<>«»‹›＜＞≪≫←→]+|前の記事|次の記事|前のページ|次のページ|前へ|次へ|[:：\s])+|(?:[<>«»‹›＜＞≪≫←→]+|[:：\s])+$`)
+
+// extractNavigation はHTMLドキュメントから前後の記事と関連記事へのリンクを抽出します。
+// プラットフォーム固有のマークアップで見つからない場合は、一般的なマークアップで抽出を試みます。
+func extractNavigation(page *articlePage, base *url.URL) (prev, next *models.PostLink, related []models.PostLink) {
+	doc := page.doc
+	markups := []navigationMarkup{genericNavigationMarkup}
+	if markup, ok := navigationMarkups[page.platform]; ok {
+		markups = []navigationMarkup{markup, genericNavigationMarkup}
+	}
+
+	for _, markup := range markups {
+		if prev == nil {
+			prev = firstPostLink(doc, markup.prev, base)
+		}
+		if next == nil {
+			next = firstPostLink(doc, markup.next, base)
+		}
+		if len(related) == 0 {
+			related = postLinks(doc, markup.related, base)
+		}
+	}
+
+	// link[rel=prev/next]（headに記述されるためタイトルはない）
+	if prev == nil {
+		if href := doc.Find("link[rel='prev']").First().AttrOr("href", ""); href != "" {
+			prev = &models.PostLink{URL: resolveURL(base, href)}
+		}
+	}
+	if next == nil {
+		if href := doc.Find("link[rel='next']").First().AttrOr("href", ""); href != "" {
+			next = &models.PostLink{URL: resolveURL(base, href)}
+		}
+	}
+
+	return prev, next, related
+}
+
+// firstPostLink はセレクタに一致する最初の記事リンクを返します
+func firstPostLink(doc *goquery.Document, selector string, base *url.URL) *models.PostLink {
+	if links := postLinks(doc, selector, base); len(links) > 0 {
+		return &links[0]
+	}
+	return nil
+}
+
+// postLinks はセレクタに一致する記事リンクの一覧を返します（URLの重複は除外）
+func postLinks(doc *goquery.Document, selector string, base *url.URL) []models.PostLink {
+	if selector == "" {
+		return nil
+	}
+
+	var links []models.PostLink
+	seen := make(map[string]bool)
+	doc.Find(selector).Each(func(i int, s *goquery.Selection) {
+		href := strings.TrimSpace(s.AttrOr("href", ""))
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") {
+			return
+		}
+		link := models.PostLink{
+			Title: postLinkTitle(s),
+			URL:   resolveURL(base, href),
+		}
+		if seen[link.URL] {
+			return
+		}
+		seen[link.URL] = true
+		links = append(links, link)
+	})
+	return links
+}
+
+// postLinkTitle はリンクから記事タイトルを取り出します。
+// title属性が定型の表記のみの場合はリンクテキストを使用します。
+func postLinkTitle(s *goquery.Selection) string {
+	for _, candidate := range []string{s.AttrOr("title", ""), s.Text()} {
+		title := strings.Join(strings.Fields(candidate), " ")
+		title = strings.TrimSpace(navigationLabelRe.ReplaceAllString(title, ""))
+		if title != "" {
+			return title
+		}
+	}
+	return ""
+}
+
+// navigationRemoveSelectors は本文から削除するナビゲーション・関連記事のセレクタを返します。
+// 汎用のナビゲーションと、pfのブログサービス固有のナビゲーションだけを対象にします。
+func navigationRemoveSelectors(pf platform) []string {
+	selectors := []string{genericNavigationMarkup.remove}
+	if markup, ok := navigationMarkups[pf]; ok {
+		selectors = append(selectors, markup.remove)
+	}
+	return selectors
+}
@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestExtractNavigation(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/2024/05/post.html")
+
+	tests := []struct {
+		name    string
+		html    string
+		prev    *models.PostLink
+		next    *models.PostLink
+		related []models.PostLink
+	}{
+		{
+			name: "WordPress形式",
+			html: `<nav class="navigation post-navigation"><div class="nav-links">
+  <div class="nav-previous"><a href="/blog/2024/04/older.html" rel="prev">« 前の記事 古い記事</a></div>
+  <div class="nav-next"><a href="/blog/2024/06/newer.html" rel="next">新しい記事 »</a></div>
+</div></nav>
+<div class="related-posts"><ul>
+  <li><a href="/blog/a.html">関連A</a></li>
+  <li><a href="/blog/b.html">関連B</a></li>
+  <li><a href="/blog/a.html">関連A（重複）</a></li>
+</ul></div>`,
+			prev: &models.PostLink{Title: "古い記事", URL: "https://example.com/blog/2024/04/older.html"},
+			next: &models.PostLink{Title: "新しい記事", URL: "https://example.com/blog/2024/06/newer.html"},
+			related: []models.PostLink{
+				{Title: "関連A", URL: "https://example.com/blog/a.html"},
+				{Title: "関連B", URL: "https://example.com/blog/b.html"},
+			},
+		},
+		{
+			name: "livedoorのtitle属性",
+			html: `<script>var ld_blog_vars = {};</script>
+<ul class="article-pager">
+  <li class="prev"><a href="https://example.com/archives/1.html" title="一つ前の記事" rel="prev">&lt;&lt; 前の記事</a></li>
+  <li class="next"><a href="https://example.com/archives/3.html" title="翌日の出来事">次の記事 &gt;&gt;</a></li>
+</ul>`,
+			prev: &models.PostLink{Title: "一つ前の記事", URL: "https://example.com/archives/1.html"},
+			next: &models.PostLink{Title: "翌日の出来事", URL: "https://example.com/archives/3.html"},
+		},
+		{
+			name: "link要素のみ",
+			html: `<head><link rel="prev" href="page1.html"><link rel="next" href="page3.html"></head>`,
+			prev: &models.PostLink{URL: "https://example.com/blog/2024/05/page1.html"},
+			next: &models.PostLink{URL: "https://example.com/blog/2024/05/page3.html"},
+		},
+		{
+			name: "ナビゲーションなし",
+			html: `<article><p>本文</p><a href="#top">トップへ</a></article>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			prev, next, related := extractNavigation(newArticlePage(doc), base)
+			if !reflect.DeepEqual(prev, tt.prev) {
+				t.Errorf("prev = %+v, want %+v", prev, tt.prev)
+			}
+			if !reflect.DeepEqual(next, tt.next) {
+				t.Errorf("next = %+v, want %+v", next, tt.next)
+			}
+			if !reflect.DeepEqual(related, tt.related) {
+				t.Errorf("related = %+v, want %+v", related, tt.related)
+			}
+		})
+	}
+}
+
+func TestExtractNavigationSamples(t *testing.T) {
+	tests := []struct {
+		file string
+		prev string
+		next string
+	}{
+		{
+			file: "12887862927.html",
+			prev: "https://ameblo.jp/macb2b37/entry-12893559535.html",
+			next: "https://ameblo.jp/macb2b37/entry-12893684529.html",
+		},
+		{
+			file: "16274503.html",
+			prev: "https://kapparin.exblog.jp/16267999/",
+			next: "https://kapparin.exblog.jp/16280171/",
+		},
+		{
+			file: "9994362.html",
+			prev: "http://kijosokuho.com/archives/9988894.html",
+			next: "http://kijosokuho.com/archives/7607553.html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			doc := loadSampleDocument(t, filepath.Join("..", "sample", "test", "testdata", tt.file))
+			prev, next, _ := extractNavigation(newArticlePage(doc), documentBaseURL(doc, ""))
+			if prev == nil || prev.URL != tt.prev || prev.Title == "" {
+				t.Errorf("prev = %+v, want URL %q with title", prev, tt.prev)
+			}
+			if next == nil || next.URL != tt.next || next.Title == "" {
+				t.Errorf("next = %+v, want URL %q with title", next, tt.next)
+			}
+		})
+	}
+}
+
+func TestCleanContentRemovesNavigation(t *testing.T) {
+	p := &HTMLParser{}
+	content := `<p>本文</p>
+<div class="pagingArea"><a class="pagingPrev" href="/a/entry-1.html">前</a></div>
+<ul class="article-pager"><li class="prev"><a href="/archives/1.html">前の記事</a></li></ul>
+<div class="pager">1 2 3</div>
+<div class="related-entries">関連エントリー</div>
+<div class="related-posts"><a href="/b.html">関連</a></div>`
+
+	all := []string{"pagingArea", "article-pager", `class="pager"`, "related-entries", "related-posts"}
+	tests := []struct {
+		pf      platform
+		removed []string
+	}{
+		{platformUnknown, []string{"related-posts"}},
+		{platformAmeblo, []string{"pagingArea", "related-posts"}},
+		{platformLivedoor, []string{"article-pager", `class="pager"`, "related-posts"}},
+		{platformHatena, []string{`class="pager"`, "related-entries", "related-posts"}},
+	}
+	for _, tt := range tests {
+		got, err := p.cleanContent(content, nil, tt.pf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range all {
+			if want := !containsString(tt.removed, s); strings.Contains(got, s) != want {
+				t.Errorf("cleanContent(%q) = %q, contains %q = %v, want %v", tt.pf, got, s, !want, want)
+			}
+		}
+		if !strings.Contains(got, "本文") {
+			t.Errorf("cleanContent(%q) = %q, should keep body text", tt.pf, got)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// noteのページに埋め込まれたNuxt.jsの初期データの変数名
+const noteNuxtPrefix = "window.__NUXT__"
+
+// __NUXT__はJavaScriptのオブジェクトリテラルのため、キーは引用符の有無どちらにも一致させる
+const noteStringValue = `"?\s*:\s*"((?:[^"\\]|\\.)*)"`
+
+var (
+	notePublishAtRe = regexp.MustCompile(`\b"?(?:publish_at|publishAt)` + noteStringValue)
+	noteEyecatchRe  = regexp.MustCompile(`\b"?eyecatch` + noteStringValue)
+	noteCreatorRe   = regexp.MustCompile(`\b"?user"?\s*:\s*\{[^{}]*?\bnickname` + noteStringValue)
+	noteHashtagRe   = regexp.MustCompile(`\b"?hashtag"?\s*:\s*\{[^{}]*?\bname` + noteStringValue)
+)
+
+// noteScriptMeta はnoteの__NUXT__とJSON-LDから記事データを取り出します。
+// __NUXT__の値が変数で参照されている場合は、JSON-LDの値を使用します。
+func noteScriptMeta(doc *goquery.Document) articleMeta {
+	var meta articleMeta
+
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		script := s.Text()
+		if !strings.Contains(script, noteNuxtPrefix) {
+			return true
+		}
+		if t, err := parseDateString(noteScriptString(notePublishAtRe, script)); err == nil {
+			meta.date = t
+		}
+		meta.author = noteScriptString(noteCreatorRe, script)
+		meta.image = noteScriptString(noteEyecatchRe, script)
+		for _, matches := range noteHashtagRe.FindAllStringSubmatch(script, -1) {
+			if tag := unquoteNoteString(matches[1]); tag != "" && !containsString(meta.tags, tag) {
+				meta.tags = append(meta.tags, tag)
+			}
+		}
+		return false
+	})
+
+	if article := jsonLDArticle(doc); article != nil {
+		if meta.title == "" {
+			meta.title = jsonString(article["headline"])
+		}
+		if meta.author == "" {
+			meta.author = jsonLDName(article["author"])
+		}
+		if meta.date.IsZero() {
+			if t, err := parseDateString(jsonString(article["datePublished"])); err == nil {
+				meta.date = t
+			}
+		}
+		if meta.image == "" {
+			meta.image = jsonString(article["image"])
+		}
+	}
+	return meta
+}
+
+// noteScriptString は正規表現に最初に一致した文字列リテラルの値を返します
+func noteScriptString(re *regexp.Regexp, script string) string {
+	if matches := re.FindStringSubmatch(script); len(matches) > 1 {
+		return unquoteNoteString(matches[1])
+	}
+	return ""
+}
+
+// unquoteNoteString はJavaScriptの文字列リテラルのエスケープ（\u002Fなど）を元に戻します
+func unquoteNoteString(s string) string {
+	if unquoted, err := strconv.Unquote(`"` + s + `"`); err == nil {
+		s = unquoted
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestNoteScriptMeta(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want articleMeta
+	}{
+		{
+			name: "JSON形式の__NUXT__",
+			html: `<script>window.__NUXT__={"data":[{"note":{"publishAt":"2025-04-13T09:18:05Z","user":{"nickname":"まっく"},"hashtag_notes":[{"hashtag":{"name":"#日記"}}]}}]}</script>`,
+			want: articleMeta{author: "まっく", date: time.Date(2025, 4, 13, 9, 18, 5, 0, time.UTC), tags: []string{"#日記"}},
+		},
+		{
+			name: "JSON-LDのみ",
+			html: `<script type="application/ld+json">{"@type":"Article","headline":"見出し","datePublished":"2025-04-13T09:18:05Z","author":{"name":"著者"},"image":"https://assets.st-note.com/a.jpg"}</script>`,
+			want: articleMeta{title: "見出し", author: "著者", date: time.Date(2025, 4, 13, 9, 18, 5, 0, time.UTC), image: "https://assets.st-note.com/a.jpg"},
+		},
+		{
+			name: "データなし",
+			html: `<p>本文</p>`,
+			want: articleMeta{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := noteScriptMeta(doc)
+			if !got.date.Equal(tt.want.date) {
+				t.Errorf("date = %v, want %v", got.date, tt.want.date)
+			}
+			got.date, tt.want.date = time.Time{}, time.Time{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("noteScriptMeta() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package parser
+
+// Option はHTMLParserの動作を変更するための関数型オプションです。
+type Option func(*HTMLParser)
+
+// WithURLRewriter は本文中のリンク・画像URLを書き換えるURLRewriterを設定します。
+// 書き換えはCleanContentの実行時に適用されます。
+func WithURLRewriter(rw URLRewriter) Option {
+	return func(p *HTMLParser) {
+		p.urlRewriter = rw
+	}
+}
+
+// WithBaseURL は相対URLを解決するための基準URL（記事の元URL）を設定します。
+// ドキュメント内の<base href>はこのURLに対して解決されます。
+func WithBaseURL(baseURL string) Option {
+	return func(p *HTMLParser) {
+		p.baseURL = baseURL
+	}
+}
+
+// WithSlugStrategy はスラッグの生成方式を設定します。
+// 指定しない場合、ParseFileはファイル名をスラッグとして使用します。
+// 同じパーサーで解析した記事のスラッグは重複しないように連番が付与されます。
+func WithSlugStrategy(strategy SlugStrategy) Option {
+	return func(p *HTMLParser) {
+		p.slugs = NewSlugGenerator(strategy)
+	}
+}
+
+// WithHeadingIDs は本文の見出しにアンカー用のid属性を付与します。
+// 付与されるIDはBlogPost.Outlineの各見出しのIDと一致します。
+func WithHeadingIDs() Option {
+	return func(p *HTMLParser) {
+		p.headingIDs = true
+	}
+}
+
+// WithReadingSpeed は読了時間の目安の算出に使用する1分あたりの文字数を設定します。
+// 0以下を指定した場合は既定値（500文字/分）を使用します。
+func WithReadingSpeed(charsPerMinute int) Option {
+	return func(p *HTMLParser) {
+		p.readingSpeed = charsPerMinute
+	}
+}
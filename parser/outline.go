@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// 見出しとして扱う要素
+const headingSelector = "h1, h2, h3, h4, h5, h6"
+
+// ExtractOutline は本文HTMLから見出しの階層構造を抽出します。
+// 見出しにid属性がない場合は、見出しのテキストからアンカーIDを生成します。
+func (p *HTMLParser) ExtractOutline(content string) []models.Heading {
+	outline, _, err := buildOutline(content, false)
+	if err != nil {
+		return nil
+	}
+	return outline
+}
+
+// buildOutline は本文HTMLから見出しの階層構造を抽出します。
+// injectIDsがtrueの場合は、生成したアンカーIDを見出しに付与したHTMLを返します。
+func buildOutline(content string, injectIDs bool) ([]models.Heading, string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrParseHTML, err)
+	}
+
+	// 既存のidと重複しないようにする
+	used := make(map[string]bool)
+	doc.Find("[id]").Each(func(i int, s *goquery.Selection) {
+		used[s.AttrOr("id", "")] = true
+	})
+
+	var flat []models.Heading
+	doc.Find(headingSelector).Each(func(i int, s *goquery.Selection) {
+		text := strings.Join(strings.Fields(s.Text()), " ")
+		if text == "" {
+			return
+		}
+
+		id, exists := s.Attr("id")
+		if !exists || strings.TrimSpace(id) == "" {
+			id = uniqueAnchorID(headingAnchorID(text), used)
+			if injectIDs {
+				s.SetAttr("id", id)
+			}
+		}
+
+		level, _ := strconv.Atoi(goquery.NodeName(s)[1:])
+		flat = append(flat, models.Heading{
+			Level: level,
+			Text:  text,
+			ID:    id,
+		})
+	})
+
+	if !injectIDs {
+		return nestHeadings(flat), content, nil
+	}
+
+	html, err := doc.Find("body").Html()
+	if err != nil {
+		return nil, "", fmt.Errorf("HTMLの生成に失敗しました: %w", err)
+	}
+	return nestHeadings(flat), html, nil
+}
+
+// nestHeadings は見出しレベルに従って見出しを入れ子にします
+func nestHeadings(flat []models.Heading) []models.Heading {
+	var headings []models.Heading
+	for i := 0; i < len(flat); {
+		heading := flat[i]
+		j := i + 1
+		for j < len(flat) && flat[j].Level > heading.Level {
+			j++
+		}
+		heading.Children = nestHeadings(flat[i+1 : j])
+		headings = append(headings, heading)
+		i = j
+	}
+	return headings
+}
+
+// headingAnchorID は見出しのテキストからアンカーIDを生成します。
+// 文字と数字は日本語を含めてそのまま残し、それ以外の文字の連続は「-」に置き換えます。
+func headingAnchorID(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "heading"
+	}
+	return strings.Join(words, "-")
+}
+
+// uniqueAnchorID は使用済みのIDと重複しないように連番を付与します
+func uniqueAnchorID(id string, used map[string]bool) string {
+	candidate := id
+	for n := 2; used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+	used[candidate] = true
+	return candidate
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestExtractOutline(t *testing.T) {
+	content := `<h2>はじめに</h2><p>本文</p>
+<h3>準備 する もの</h3>
+<h3 id="custom">手順</h3>
+<h4>Step 1: 登録</h4>
+<h2>はじめに</h2>
+<h1>  </h1>
+<h2>！！</h2>`
+
+	want := []models.Heading{
+		{Level: 2, Text: "はじめに", ID: "はじめに", Children: []models.Heading{
+			{Level: 3, Text: "準備 する もの", ID: "準備-する-もの"},
+			{Level: 3, Text: "手順", ID: "custom", Children: []models.Heading{
+				{Level: 4, Text: "Step 1: 登録", ID: "step-1-登録"},
+			}},
+		}},
+		{Level: 2, Text: "はじめに", ID: "はじめに-2"},
+		{Level: 2, Text: "！！", ID: "heading"},
+	}
+
+	got := (&HTMLParser{}).ExtractOutline(content)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractOutline() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNestHeadings(t *testing.T) {
+	// 上位の見出しより前に下位の見出しがある場合もそのまま並べる
+	flat := []models.Heading{
+		{Level: 3, Text: "a"},
+		{Level: 2, Text: "b"},
+		{Level: 4, Text: "c"},
+		{Level: 3, Text: "d"},
+	}
+	want := []models.Heading{
+		{Level: 3, Text: "a"},
+		{Level: 2, Text: "b", Children: []models.Heading{
+			{Level: 4, Text: "c"},
+			{Level: 3, Text: "d"},
+		}},
+	}
+	if got := nestHeadings(flat); !reflect.DeepEqual(got, want) {
+		t.Errorf("nestHeadings() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuildOutlineInjectIDs(t *testing.T) {
+	content := `<div id="intro">導入</div><h2>Intro</h2><h2 id="keep">維持</h2>`
+
+	outline, html, err := buildOutline(content, true)
+	if err != nil {
+		t.Fatalf("buildOutline() error = %v", err)
+	}
+	want := `<div id="intro">導入</div><h2 id="intro-2">Intro</h2><h2 id="keep">維持</h2>`
+	if html != want {
+		t.Errorf("buildOutline() html = %v, want %v", html, want)
+	}
+	if len(outline) != 2 || outline[0].ID != "intro-2" || outline[1].ID != "keep" {
+		t.Errorf("buildOutline() outline = %+v", outline)
+	}
+
+	// 付与しない場合は本文を変更しない
+	_, html, err = buildOutline(content, false)
+	if err != nil {
+		t.Fatalf("buildOutline() error = %v", err)
+	}
+	if html != content {
+		t.Errorf("buildOutline() changed content: %v", html)
+	}
+}
+
+func TestParseFileWithHeadingIDs(t *testing.T) {
+	file := filepath.Join("..", "sample", "test", "testdata", "12887862927.html")
+	post, err := New(WithHeadingIDs()).ParseFile(context.Background(), file)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if len(post.Outline) == 0 {
+		t.Fatal("Outline is empty")
+	}
+	for _, h := range post.Outline {
+		if !strings.Contains(post.Content, `id="`+h.ID+`"`) {
+			t.Errorf("content does not contain id %q", h.ID)
+		}
+	}
+}
@@ -26,14 +26,28 @@ type Parser interface {
 
 // HTMLParser はHTMLファイルからブログ記事を解析するパーサーです。
 type HTMLParser struct {
-	logger *zap.Logger
+	logger       *zap.Logger
+	urlRewriter  URLRewriter
+	baseURL      string
+	slugs        *SlugGenerator
+	headingIDs   bool
+	readingSpeed int
 }
 
 // New は新しいHTMLParserを作成します。
-func New() Parser {
-	return &HTMLParser{
+func New(opts ...Option) Parser {
+	return newHTMLParser(opts)
+}
+
+// newHTMLParser はオプションを適用したHTMLParserを作成します
+func newHTMLParser(opts []Option) *HTMLParser {
+	p := &HTMLParser{
 		logger: zap.NewNop(),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // ParseFile はファイルパスからブログ記事を解析します。
@@ -56,21 +70,31 @@ func (p *HTMLParser) ParseFile(ctx context.Context, path string) (*models.BlogPo
 		return nil, fmt.Errorf("ファイル %s の解析に失敗: %w", path, err)
 	}
 
-	post.Slug = filepath.Base(path)
+	if p.slugs == nil {
+		post.Slug = filepath.Base(path)
+	}
 	return post, nil
 }
 
 // Parse はio.Readerからブログ記事を解析します。
+// 文字コードはUTF-8・metaで宣言された文字コード・Shift_JIS・EUC-JPの順に判定します。
 func (p *HTMLParser) Parse(ctx context.Context, r io.Reader) (*models.BlogPost, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	doc, err := goquery.NewDocumentFromReader(r)
+	// EUC-JP・Shift_JISなどの古いブログのページはUTF-8に変換してから解析する
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("HTMLの読み込みに失敗しました: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(decodeHTML(data)))
 	if err != nil {
 		return nil, fmt.Errorf("HTMLのパースに失敗しました: %w", err)
 	}
+	// プラットフォームの判定と記事データの解析は一度だけ行い、各抽出処理で共有する
+	page := newArticlePage(doc)
 
-	title, err := extractTitle(doc)
+	title, err := extractTitle(page)
 	if err != nil {
 		return nil, fmt.Errorf("タイトルの抽出に失敗しました: %w", err)
 	}
@@ -80,17 +104,24 @@ func (p *HTMLParser) Parse(ctx context.Context, r io.Reader) (*models.BlogPost,
 		return nil, errors.New("無効なタイトルです")
 	}
 
-	content, err := extractContent(doc)
+	content, err := extractContent(page)
 	if err != nil {
 		return nil, fmt.Errorf("コンテンツの抽出に失敗しました: %w", err)
 	}
 
-	// コンテンツのクリーニング
-	content, err = p.CleanContent(content)
+	// コンテンツのクリーニング（相対URLはドキュメントの基準URLで解決する）
+	base := documentBaseURL(doc, p.baseURL)
+	content, err = p.cleanContent(content, base, page.platform)
 	if err != nil {
 		return nil, fmt.Errorf("コンテンツのクリーニングに失敗しました: %w", err)
 	}
 
+	// 見出しの抽出（必要に応じて本文の見出しにIDを付与する）
+	outline, content, err := buildOutline(content, p.headingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("見出しの抽出に失敗しました: %w", err)
+	}
+
 	// サマリ生成
 	summary, err := p.GenerateSummary(content)
 	if err != nil {
@@ -101,7 +132,12 @@ func (p *HTMLParser) Parse(ctx context.Context, r io.Reader) (*models.BlogPost,
 		return nil, errors.New("無効なコンテンツです")
 	}
 
-	categories, err := extractCategories(doc)
+	stats, err := p.ComputeStats(content)
+	if err != nil {
+		return nil, fmt.Errorf("統計情報の算出に失敗しました: %w", err)
+	}
+
+	categories, err := extractCategories(page)
 	if err != nil {
 		return nil, fmt.Errorf("カテゴリの抽出に失敗しました: %w", err)
 	}
@@ -115,7 +151,7 @@ func (p *HTMLParser) Parse(ctx context.Context, r io.Reader) (*models.BlogPost,
 		}
 	}
 
-	tags, err := extractTags(doc)
+	tags, err := extractTags(page)
 	if err != nil {
 		return nil, fmt.Errorf("タグの抽出に失敗しました: %w", err)
 	}
@@ -128,26 +164,56 @@ func (p *HTMLParser) Parse(ctx context.Context, r io.Reader) (*models.BlogPost,
 		}
 	}
 
-	createdAt, err := extractDate(doc)
+	createdAt, err := extractDate(page)
 	if err != nil {
 		createdAt = time.Time{} // 日付が見つからない場合はゼロ値
 	}
+	// 公開日時より前の更新日時（エキサイトブログのdateModifiedなど）は使用しない
+	updatedAt := extractUpdatedDate(page)
+	if updatedAt.Before(createdAt) {
+		updatedAt = time.Time{}
+	}
 
-	html, _ := doc.Html()
-	images := p.ExtractImages(html)
-	firstImage := ""
-	if len(images) > 0 {
-		firstImage = images[0].URL
+	// プラットフォーム固有のアイキャッチ画像がない場合はOGP・本文の画像を使用する
+	firstImage := extractFeaturedImage(page, base)
+	if firstImage == "" {
+		html, _ := doc.Html()
+		if images := p.ExtractImages(html); len(images) > 0 {
+			firstImage = images[0].URL
+		}
 	}
 
+	prevPost, nextPost, relatedPosts := extractNavigation(page, base)
+	comments := extractComments(page)
+
 	post := &models.BlogPost{
-		Title:      title,
-		Content:    content,
-		Summary:    summary,
-		Categories: validCategories,
-		Tags:       validTags,
-		CreatedAt:  createdAt,
-		FirstImage: firstImage,
+		Title:        title,
+		Subtitle:     extractSubtitle(page),
+		Author:       extractAuthor(page),
+		PostID:       extractPostID(page),
+		Content:      content,
+		Summary:      summary,
+		Categories:   validCategories,
+		Tags:         validTags,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		FirstImage:   firstImage,
+		URL:          resolveURL(base, extractPermalink(doc)),
+		CanonicalURL: resolveURL(base, extractCanonicalURL(doc)),
+		SiteName:     extractSiteName(page),
+		Outline:      outline,
+		Embeds:       p.ExtractEmbeds(content),
+		Comments:     comments,
+		PrevPost:     prevPost,
+		NextPost:     nextPost,
+		RelatedPosts: relatedPosts,
+		Engagement:   extractEngagement(page, comments),
+		Stats:        stats,
+		Published:    true,
+	}
+
+	if p.slugs != nil {
+		post.Slug = p.slugs.Generate(post)
 	}
 
 	return post, nil
@@ -13,12 +13,16 @@ import (
 type parseTest struct {
 	file       string
 	title      string
+	author     string
 	length     int
 	categories []string
 	tags       []string
 	tagCount   int
 	firstImage string
 	createdAt  time.Time
+	url        string
+	canonical  string
+	siteName   string
 }
 
 func TestParseFileSamples(t *testing.T) {
@@ -27,32 +31,44 @@ func TestParseFileSamples(t *testing.T) {
 		{
 			file:       filepath.Join("..", "sample", "test", "testdata", "12403291408.html"),
 			title:      "『●あなた仕様にカスタマイズ！！『思い込み』書き換え・マンツーマン講座』",
+			author:     "中井亜紀",
 			length:     43317,
 			categories: []string{"マンツーマン講座"},
 			tags:       []string{"不登校"},
 			tagCount:   1,
 			firstImage: "https://stat.ameba.jp/user_images/20180907/17/akinakai/eb/9a/j/o0480047014261879529.jpg",
 			createdAt:  time.Date(2024, 5, 22, 12, 39, 1, 0, tz),
+			url:        "https://ameblo.jp/akinakai/entry-12403291408.html",
+			canonical:  "https://ameblo.jp/akinakai/entry-12403291408.html",
+			siteName:   "山形発！　女性のための「毎日がもっと楽しくなる」カウンセラーブログ",
 		},
 		{
 			file:       filepath.Join("..", "sample", "test", "testdata", "12887862927.html"),
 			title:      "『ルーティーン』",
+			author:     "ワフウフ",
 			length:     18409,
 			categories: []string{"ブログ"},
 			tags:       []string{"認知症介護", "認知症の母", "認知症"},
 			tagCount:   3,
 			firstImage: "https://stat.ameba.jp/user_images/20250412/13/macb2b37/d3/da/j/o1024102415565487103.jpg",
 			createdAt:  time.Date(2025, 4, 13, 18, 18, 5, 0, tz),
+			url:        "https://ameblo.jp/macb2b37/entry-12887862927.html",
+			canonical:  "https://ameblo.jp/macb2b37/entry-12887862927.html",
+			siteName:   "アルツフルデイズ",
 		},
 		{
 			file:       filepath.Join("..", "sample", "test", "testdata", "16274503.html"),
 			title:      "月山に思いを馳せる満月の夜",
-			length:     11715,
+			author:     "suiu",
+			length:     11076,
 			categories: nil,
 			tags:       nil,
 			tagCount:   0,
 			firstImage: "https://pds.exblog.jp/pds/1/201109/12/14/b0207514_21282826.jpg",
 			createdAt:  time.Date(2011, 9, 12, 23, 31, 0, 0, tz),
+			url:        "https://kapparin.exblog.jp/16274503/",
+			canonical:  "https://kapparin.exblog.jp/16274503/",
+			siteName:   "心理カウンセラー・中井亜紀『成長の記録』",
 		},
 		{
 			file:       filepath.Join("..", "sample", "test", "testdata", "9994362.html"),
@@ -63,6 +79,53 @@ func TestParseFileSamples(t *testing.T) {
 			tagCount:   68,
 			firstImage: "https://parts.blog.livedoor.jp/img/usr/cmn/ogp_image/livedoor.png",
 			createdAt:  time.Date(2018, 6, 17, 2, 17, 45, 0, tz),
+			url:        "http://kijosokuho.com/archives/9994362.html",
+			canonical:  "http://kijosokuho.com/archives/9994362.html",
+			siteName:   "鬼女速報 ― キチママ・修羅場まとめ ―",
+		},
+		{
+			file:       filepath.Join("..", "sample", "test", "testdata", "115123456.html"),
+			title:      "春の植え替え",
+			author:     "みどり",
+			length:     408,
+			categories: []string{"園芸"},
+			tags:       []string{"ローズマリー"},
+			firstImage: "http://niwa-kiroku.up.seesaa.net/image/rosemary.jpg",
+			createdAt:  time.Date(2009, 3, 5, 12, 34, 0, 0, tz),
+			url:        "http://niwa-kiroku.seesaa.net/article/115123456.html",
+			canonical:  "http://niwa-kiroku.seesaa.net/article/115123456.html",
+			siteName:   "庭いじりの記録",
+		},
+		{
+			file:       filepath.Join("..", "sample", "test", "testdata", "eid1234.html"),
+			title:      "雨の日の過ごし方",
+			author:     "はるか",
+			length:     321,
+			categories: []string{"ねこ"},
+			createdAt:  time.Date(2009, 3, 5, 23, 5, 0, 0, tz),
+			url:        "http://nekobiyori.jugem.jp/?eid=1234",
+			canonical:  "http://nekobiyori.jugem.jp/?eid=1234",
+		},
+		{
+			file:       filepath.Join("..", "sample", "test", "testdata", "0a1b2c3d4e5f60718293a4b5c6d7e8f9.html"),
+			title:      "高尾山で梅を見る",
+			length:     308,
+			categories: []string{"低山ハイキング"},
+			createdAt:  time.Date(2009, 3, 5, 8, 15, 0, 0, tz),
+			url:        "https://blog.goo.ne.jp/yamaaruki/e/0a1b2c3d4e5f60718293a4b5c6d7e8f9",
+			canonical:  "https://blog.goo.ne.jp/yamaaruki/e/0a1b2c3d4e5f60718293a4b5c6d7e8f9",
+			siteName:   "山歩き手帖",
+		},
+		{
+			file:       filepath.Join("..", "sample", "test", "testdata", "60123456.html"),
+			title:      "春の只見線",
+			length:     366,
+			categories: []string{"鉄道"},
+			firstImage: "https://blogs.c.yimg.jp/res/blog-12-34/tetsu_photo/folder/123/45/60123456/img_0.jpg",
+			createdAt:  time.Date(2009, 3, 5, 12, 34, 0, 0, tz),
+			url:        "https://blogs.yahoo.co.jp/tetsu_photo/60123456.html",
+			canonical:  "https://blogs.yahoo.co.jp/tetsu_photo/60123456.html",
+			siteName:   "鉄道写真のある暮らし",
 		},
 	}
 
@@ -76,6 +139,9 @@ func TestParseFileSamples(t *testing.T) {
 		if post.Title != tt.title {
 			t.Errorf("%s title=%q want %q", tt.file, post.Title, tt.title)
 		}
+		if post.Author != tt.author {
+			t.Errorf("%s author=%q want %q", tt.file, post.Author, tt.author)
+		}
 		if len(post.Content) != tt.length {
 			t.Errorf("%s length=%d want %d", tt.file, len(post.Content), tt.length)
 		}
@@ -95,6 +161,40 @@ func TestParseFileSamples(t *testing.T) {
 		if !post.CreatedAt.Equal(tt.createdAt) {
 			t.Errorf("%s createdAt=%v want %v", tt.file, post.CreatedAt, tt.createdAt)
 		}
+		if post.URL != tt.url {
+			t.Errorf("%s url=%q want %q", tt.file, post.URL, tt.url)
+		}
+		if post.CanonicalURL != tt.canonical {
+			t.Errorf("%s canonical=%q want %q", tt.file, post.CanonicalURL, tt.canonical)
+		}
+		if post.SiteName != tt.siteName {
+			t.Errorf("%s siteName=%q want %q", tt.file, post.SiteName, tt.siteName)
+		}
+	}
+}
+
+func TestParseFileSamplesRemovesAds(t *testing.T) {
+	tests := []struct {
+		file     string
+		unwanted []string
+	}{
+		{"115123456.html", []string{"スポンサードリンク", "posted by", "Powered by"}},
+		{"eid1234.html", []string{"PR", "無料でブログ", "posted by", "雨の日の過ごし方"}},
+		{"0a1b2c3d4e5f60718293a4b5c6d7e8f9.html", []string{"ブックマークに追加", "goo ブログに投稿"}},
+		{"60123456.html", []string{"ナイス", "トラックバック"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			post, err := New().ParseFile(context.Background(), filepath.Join("..", "sample", "test", "testdata", tt.file))
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+			for _, s := range tt.unwanted {
+				if strings.Contains(post.Content, s) {
+					t.Errorf("Content contains %q: %s", s, post.Content)
+				}
+			}
+		})
 	}
 }
 
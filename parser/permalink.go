@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// ld_blog_vars（livedoorブログ）の記事パーマリンク
+	ldArticlePermalinkRe = regexp.MustCompile(`articles\s*:\s*\[\s*\{\s*[^}]*?permalink\s*:\s*'([^']*)'`)
+	// ld_blog_vars（livedoorブログ）のブログ名
+	ldBlogTitleRe = regexp.MustCompile(`(?m)^\s*title\s*:\s*'([^']*)'`)
+)
+
+// extractCanonicalURL はHTMLドキュメントから正規URLを抽出します。
+// 以下の優先順位で抽出を試みます：
+// 1. link[rel=canonical]
+// 2. og:urlメタタグの内容
+func extractCanonicalURL(doc *goquery.Document) string {
+	if href := strings.TrimSpace(doc.Find("link[rel='canonical']").First().AttrOr("href", "")); href != "" {
+		return href
+	}
+	return strings.TrimSpace(doc.Find("meta[property='og:url']").First().AttrOr("content", ""))
+}
+
+// extractPermalink はHTMLドキュメントから記事のパーマリンクを抽出します。
+// 以下の優先順位で抽出を試みます：
+// 1. プラットフォーム固有の変数（アメブロのINIT_DATA、livedoorのld_blog_vars）
+// 2. JSON-LDのurl / mainEntityOfPage
+// 3. og:urlメタタグの内容
+// 4. link[rel=canonical]
+func extractPermalink(doc *goquery.Document) string {
+	// 1. プラットフォーム固有の変数
+	if data := amebloInitData(doc); data != nil {
+		if entry, blog := amebloEntry(data); entry != nil {
+			if permalink := amebloPermalink(entry, blog); permalink != "" {
+				return permalink
+			}
+		}
+	}
+	if vars := ldBlogVars(doc); vars != "" {
+		if matches := ldArticlePermalinkRe.FindStringSubmatch(vars); len(matches) > 1 {
+			if permalink := strings.TrimSpace(matches[1]); permalink != "" {
+				return permalink
+			}
+		}
+	}
+
+	// 2. JSON-LDのurl / mainEntityOfPage
+	if article := jsonLDArticle(doc); article != nil {
+		for _, key := range []string{"url", "mainEntityOfPage"} {
+			if u := jsonString(article[key]); u != "" {
+				return u
+			}
+		}
+	}
+
+	// 3. og:url
+	if ogURL := strings.TrimSpace(doc.Find("meta[property='og:url']").First().AttrOr("content", "")); ogURL != "" {
+		return ogURL
+	}
+
+	// 4. link[rel=canonical]
+	return strings.TrimSpace(doc.Find("link[rel='canonical']").First().AttrOr("href", ""))
+}
+
+// extractSiteName はHTMLドキュメントからサイト（ブログ）名を抽出します。
+// プラットフォーム固有のマークアップや記事データでパブリケーション名（Medium・Substack）が見つかった場合はそれを優先し、
+// 見つからない場合は以下の優先順位で抽出を試みます：
+// 1. og:site_nameメタタグの内容
+// 2. JSON-LDのpublisher.name
+// 3. プラットフォーム固有の変数（アメブロのINIT_DATA、livedoorのld_blog_vars）
+func extractSiteName(page *articlePage) string {
+	doc := page.doc
+	if page.hasMarkup {
+		if siteName := markupText(doc, page.markup.site); siteName != "" {
+			return siteName
+		}
+		if siteName := page.meta.siteName; siteName != "" {
+			return siteName
+		}
+	}
+
+	// 1. og:site_name
+	if siteName := strings.TrimSpace(doc.Find("meta[property='og:site_name']").First().AttrOr("content", "")); siteName != "" {
+		return siteName
+	}
+
+	// 2. JSON-LDのpublisher
+	if article := jsonLDArticle(doc); article != nil {
+		if publisher := jsonObject(article, "publisher"); publisher != nil {
+			if name := jsonString(publisher["name"]); name != "" {
+				return name
+			}
+		}
+	}
+
+	// 3. プラットフォーム固有の変数
+	if data := amebloInitData(doc); data != nil {
+		if _, blog := amebloEntry(data); blog != nil {
+			if title := jsonString(blog["blog_title"]); title != "" {
+				return title
+			}
+		}
+	}
+	if vars := ldBlogVars(doc); vars != "" {
+		// 記事タイトルと区別するため、articlesより前に定義されたtitleのみを対象にする
+		if idx := strings.Index(vars, "articles"); idx != -1 {
+			vars = vars[:idx]
+		}
+		if matches := ldBlogTitleRe.FindStringSubmatch(vars); len(matches) > 1 {
+			return strings.TrimSpace(matches[1])
+		}
+	}
+
+	return ""
+}
+
+// ldBlogVars はld_blog_varsを定義しているscriptのテキストを返します
+func ldBlogVars(doc *goquery.Document) string {
+	var vars string
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if script := s.Text(); strings.Contains(script, "ld_blog_vars") {
+			vars = script
+			return false
+		}
+		return true
+	})
+	return vars
+}
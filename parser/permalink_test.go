@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestExtractPermalink(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "アメブロのINIT_DATA",
+			html: `<script>window.INIT_DATA={"entryState":{"entryMap":{"12887862927":{"entry_id":12887862927,"blog_id":10058668918}}},` +
+				`"bloggerState":{"blogMap":{"10058668918":{"blog_name":"macb2b37","blog_title":"アルツフルデイズ"}}}};window.x=1;</script>` +
+				`<meta property="og:url" content="https://example.com/og">`,
+			want: "https://ameblo.jp/macb2b37/entry-12887862927.html",
+		},
+		{
+			name: "アメブロのINIT_DATA（前後の記事を含む）",
+			html: `<script>window.INIT_DATA={"entryState":{"entryMap":{"1":{"entry_id":1,"blog_id":9},"2":{"entry_id":2,"blog_id":9},"3":{"entry_id":3,"blog_id":9}}},` +
+				`"bloggerState":{"blogMap":{"9":{"blog_name":"user"}}},"router":{"location":{"pathname":"/user/entry-2.html"}}};</script>`,
+			want: "https://ameblo.jp/user/entry-2.html",
+		},
+		{
+			name: "livedoorのld_blog_vars",
+			html: `<script>var ld_blog_vars = {
+  title : 'ブログ名',
+  articles : [ {
+       id : '9994362',
+       permalink : 'http://kijosokuho.com/archives/9994362.html',
+       categories : [ { id:'103725', name:'カテゴリ', permalink:'http://kijosokuho.com/archives/cat_103725.html' } ],
+       title : '記事タイトル'
+}   ]
+};</script>`,
+			want: "http://kijosokuho.com/archives/9994362.html",
+		},
+		{
+			name: "JSON-LDのurl",
+			html: `<script type="application/ld+json">{"@context":"https://schema.org","@graph":[` +
+				`{"@type":"WebSite","url":"https://example.com/"},` +
+				`{"@type":"BlogPosting","mainEntityOfPage":{"@id":"https://example.com/posts/1"}}]}</script>`,
+			want: "https://example.com/posts/1",
+		},
+		{
+			name: "og:url",
+			html: `<link rel="canonical" href="https://example.com/canonical"><meta property="og:url" content="https://example.com/og">`,
+			want: "https://example.com/og",
+		},
+		{
+			name: "canonical",
+			html: `<link rel="canonical" href="https://example.com/canonical">`,
+			want: "https://example.com/canonical",
+		},
+		{
+			name: "見つからない場合",
+			html: `<p>本文</p>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := extractPermalink(doc); got != tt.want {
+				t.Errorf("extractPermalink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"canonicalを優先", `<meta property="og:url" content="https://example.com/og"><link rel="canonical" href="https://example.com/c">`, "https://example.com/c"},
+		{"og:urlで代替", `<meta property="og:url" content="https://example.com/og">`, "https://example.com/og"},
+		{"見つからない場合", `<p>本文</p>`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := extractCanonicalURL(doc); got != tt.want {
+				t.Errorf("extractCanonicalURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSiteName(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "og:site_name",
+			html: `<meta property="og:site_name" content="サイト名">`,
+			want: "サイト名",
+		},
+		{
+			name: "JSON-LDのpublisher",
+			html: `<script type="application/ld+json">{"@type":"BlogPosting","publisher":{"@type":"Organization","name":"発行者"}}</script>`,
+			want: "発行者",
+		},
+		{
+			name: "アメブロのINIT_DATA",
+			html: `<script>window.INIT_DATA={"entryState":{"entryMap":{"1":{"entry_id":1,"blog_id":2}}},` +
+				`"bloggerState":{"blogMap":{"2":{"blog_name":"user","blog_title":"アメブロのブログ"}}}};</script>`,
+			want: "アメブロのブログ",
+		},
+		{
+			name: "livedoorのld_blog_vars",
+			html: `<script>var ld_blog_vars = {
+  title : 'livedoorのブログ',
+  articles : [ {
+       title : '記事タイトル'
+}   ]
+};</script>`,
+			want: "livedoorのブログ",
+		},
+		{
+			name: "見つからない場合",
+			html: `<p>本文</p>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := extractSiteName(newArticlePage(doc)); got != tt.want {
+				t.Errorf("extractSiteName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
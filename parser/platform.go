@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// platform はブログサービスの種別です
+type platform string
+
+const (
+	platformUnknown   platform = ""
+	platformAmeblo    platform = "ameblo"
+	platformLivedoor  platform = "livedoor"
+	platformExcite    platform = "excite"
+	platformHatena    platform = "hatena"
+	platformNote      platform = "note"
+	platformBlogger   platform = "blogger"
+	platformSeesaa    platform = "seesaa"
+	platformJugem     platform = "jugem"
+	platformGoo       platform = "goo"
+	platformYahoo     platform = "yahoo"
+	platformQiita     platform = "qiita"
+	platformZenn      platform = "zenn"
+	platformMedium    platform = "medium"
+	platformSubstack  platform = "substack"
+	platformWordPress platform = "wordpress"
+)
+
+// プラットフォームの判定ルール（上から順に判定する）
+var platformRules = []struct {
+	platform platform
+	hosts    []string // 記事URLのホスト名（後方一致）
+	markers  []string // プラットフォーム固有の要素のセレクタ
+	scripts  []string // scriptに含まれるプラットフォーム固有の文字列
+}{
+	{
+		platform: platformAmeblo,
+		hosts:    []string{"ameblo.jp"},
+		markers:  []string{".skin-entryBody"},
+		scripts:  []string{amebloInitDataPrefix},
+	},
+	{
+		platform: platformLivedoor,
+		hosts:    []string{"blog.livedoor.jp", "blog.jp", "doorblog.jp", "livedoor.biz"},
+		markers:  []string{"link[href*='parts.blog.livedoor.jp']"},
+		scripts:  []string{"ld_blog_vars"},
+	},
+	{
+		platform: platformExcite,
+		hosts:    []string{"exblog.jp"},
+		markers:  []string{"div.POST_BODY", "link[href*='s.eximg.jp/exblog']"},
+	},
+	{
+		platform: platformHatena,
+		hosts:    []string{"hatenablog.com", "hatenablog.jp", "hateblo.jp", "hatenadiary.com", "hatenadiary.jp"},
+		markers:  []string{"html[data-admin-domain*='blog.hatena.ne.jp']", "link[href*='cdn.blog.st-hatena.com']"},
+	},
+	{
+		platform: platformNote,
+		hosts:    []string{"note.com", "note.mu"},
+		markers:  []string{".note-common-styles__textnote-body"},
+	},
+	{
+		platform: platformBlogger,
+		hosts:    []string{"blogspot.com", "blogger.com"},
+		markers:  []string{"meta[name='generator'][content='Blogger']", "link[href*='www.blogger.com/static']", "meta[itemprop='blogId']"},
+		scripts:  []string{"_WidgetManager"},
+	},
+	{
+		platform: platformSeesaa,
+		hosts:    []string{"seesaa.net", "sblo.jp"},
+		markers:  []string{"a[href*='blog.seesaa.jp']", "script[src*='seesaa.jp']"},
+	},
+	{
+		platform: platformJugem,
+		hosts:    []string{"jugem.jp", "jugem.cc"},
+		markers:  []string{"div.entry_state", "script[src*='jugem.jp']"},
+	},
+	{
+		platform: platformGoo,
+		hosts:    []string{"blog.goo.ne.jp"},
+		markers:  []string{"link[href*='blog.goo.ne.jp']", "script[src*='blog.goo.ne.jp']"},
+	},
+	{
+		platform: platformYahoo,
+		hosts:    []string{"blogs.yahoo.co.jp"},
+		markers:  []string{"link[href*='blogs.yimg.jp']", "script[src*='blogs.yimg.jp']"},
+	},
+	{
+		platform: platformQiita,
+		hosts:    []string{"qiita.com"},
+		markers:  []string{"meta[property='og:site_name'][content='Qiita']"},
+	},
+	{
+		platform: platformZenn,
+		hosts:    []string{"zenn.dev"},
+		markers:  []string{"meta[property='og:site_name'][content='Zenn']"},
+	},
+	{
+		// 独自ドメインのパブリケーションはアプリへのリンク用のmetaで判定する
+		platform: platformMedium,
+		hosts:    []string{"medium.com"},
+		markers: []string{
+			"meta[property='al:android:package'][content='com.medium.reader']",
+			"meta[property='og:site_name'][content='Medium']", "link[href*='cdn-static-1.medium.com']",
+		},
+	},
+	{
+		platform: platformSubstack,
+		hosts:    []string{"substack.com"},
+		markers:  []string{"meta[name='generator'][content='Substack']", "link[href*='substackcdn.com']"},
+		scripts:  []string{substackPreloadsPrefix},
+	},
+	{
+		// 他のサービスでもwp-contentの画像を参照することがあるため最後に判定する
+		platform: platformWordPress,
+		hosts:    []string{"wordpress.com"},
+		markers: []string{
+			"meta[name='generator'][content^='WordPress']", "body[class*='postid-']",
+			"link[href*='/wp-content/']", "script[src*='/wp-content/']", "link[href*='/wp-includes/']",
+		},
+	},
+}
+
+// detectPlatform はHTMLドキュメントがどのブログサービスのページかを判定します。
+// 独自ドメインの場合もあるため、URLのホスト名に加えて固有の要素やscriptでも判定します。
+func detectPlatform(doc *goquery.Document) platform {
+	if doc == nil {
+		return platformUnknown
+	}
+
+	if pf := platformFromURL(extractCanonicalURL(doc)); pf != platformUnknown {
+		return pf
+	}
+
+	for _, rule := range platformRules {
+		for _, marker := range rule.markers {
+			if doc.Find(marker).Length() > 0 {
+				return rule.platform
+			}
+		}
+		for _, script := range rule.scripts {
+			if scriptContains(doc, script) {
+				return rule.platform
+			}
+		}
+	}
+	return platformUnknown
+}
+
+// platformFromURL は記事URLのホスト名からブログサービスを判定します（判定できない場合はplatformUnknown）
+func platformFromURL(rawURL string) platform {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return platformUnknown
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return platformUnknown
+	}
+	for _, rule := range platformRules {
+		for _, h := range rule.hosts {
+			if host == h || strings.HasSuffix(host, "."+h) {
+				return rule.platform
+			}
+		}
+	}
+	return platformUnknown
+}
+
+// scriptContains はいずれかのscriptに指定した文字列が含まれるかを判定します
+func scriptContains(doc *goquery.Document, substr string) bool {
+	found := false
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		found = strings.Contains(s.Text(), substr)
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,320 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want platform
+	}{
+		{"アメブロのURL", `<link rel="canonical" href="https://ameblo.jp/user/entry-1.html">`, platformAmeblo},
+		{"アメブロのINIT_DATA", `<script>window.INIT_DATA={}</script>`, platformAmeblo},
+		{"livedoorの独自ドメイン", `<link rel="canonical" href="http://kijosokuho.com/archives/1.html"><script>var ld_blog_vars = {};</script>`, platformLivedoor},
+		{"livedoorのURL", `<meta property="og:url" content="http://blog.livedoor.jp/user/archives/1.html">`, platformLivedoor},
+		{"エキサイトブログのURL", `<link rel="canonical" href="https://kapparin.exblog.jp/16274503/">`, platformExcite},
+		{"エキサイトブログのマークアップ", `<div class="POST_BODY">本文</div>`, platformExcite},
+		{"はてなブログのURL", `<link rel="canonical" href="https://example.hatenablog.com/entry/2024/05/22/123901">`, platformHatena},
+		{"はてなブログの独自ドメイン", `<html data-admin-domain="//blog.hatena.ne.jp"><body></body></html>`, platformHatena},
+		{"noteのURL", `<link rel="canonical" href="https://note.com/user/n/n1234567890ab">`, platformNote},
+		{"BloggerのURL", `<link rel="canonical" href="https://example.blogspot.com/2025/04/blog-post.html">`, platformBlogger},
+		{"Bloggerの独自ドメイン", `<meta name="generator" content="Blogger">`, platformBlogger},
+		{"SeesaaブログのURL", `<link rel="canonical" href="http://example.seesaa.net/article/115123456.html">`, platformSeesaa},
+		{"JUGEMのマークアップ", `<div class="entry_state">posted by user | 12:34</div>`, platformJugem},
+		{"gooブログのURL", `<link rel="canonical" href="https://blog.goo.ne.jp/user/e/0a1b2c3d">`, platformGoo},
+		{"Yahoo!ブログのURL", `<link rel="canonical" href="https://blogs.yahoo.co.jp/user/60123456.html">`, platformYahoo},
+		{"QiitaのURL", `<link rel="canonical" href="https://qiita.com/user/items/c686397e4a0f4f11683d">`, platformQiita},
+		{"Zennのog:site_name", `<meta property="og:site_name" content="Zenn">`, platformZenn},
+		{"Mediumの独自ドメイン", `<meta property="al:android:package" content="com.medium.reader">`, platformMedium},
+		{"Substackのgenerator", `<meta name="generator" content="Substack">`, platformSubstack},
+		{"Substackの_preloads", `<script>window._preloads = JSON.parse("{}")</script>`, platformSubstack},
+		{"WordPressのgenerator", `<meta name="generator" content="WordPress 6.5.2">`, platformWordPress},
+		{"WordPressのbodyのclass", `<body class="post-template-default single single-post postid-123"></body>`, platformWordPress},
+		{"WordPressのwp-content", `<link rel="stylesheet" href="https://example.com/wp-content/themes/twentytwentyfour/style.css">`, platformWordPress},
+		{"wp-contentを参照するはてなブログ", `<html data-admin-domain="//blog.hatena.ne.jp"><body><img src="https://example.com/wp-content/uploads/a.jpg"></body></html>`, platformHatena},
+		{"不明", `<article>本文</article>`, platformUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := detectPlatform(doc); got != tt.want {
+				t.Errorf("detectPlatform() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := detectPlatform(nil); got != platformUnknown {
+		t.Errorf("detectPlatform(nil) = %q", got)
+	}
+}
+
+func TestDetectPlatformSamples(t *testing.T) {
+	tests := []struct {
+		file string
+		want platform
+	}{
+		{"12403291408.html", platformAmeblo},
+		{"12887862927.html", platformAmeblo},
+		{"16274503.html", platformExcite},
+		{"9994362.html", platformLivedoor},
+		{"115123456.html", platformSeesaa},
+		{"eid1234.html", platformJugem},
+		{"0a1b2c3d4e5f60718293a4b5c6d7e8f9.html", platformGoo},
+		{"60123456.html", platformYahoo},
+	}
+	for _, tt := range tests {
+		doc := loadSampleDocument(t, filepath.Join("..", "sample", "test", "testdata", tt.file))
+		if got := detectPlatform(doc); got != tt.want {
+			t.Errorf("%s detectPlatform() = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestParsePlatformSamples(t *testing.T) {
+	jst := time.FixedZone("JST", 9*3600)
+	tests := []struct {
+		file        string
+		platform    platform
+		title       string
+		subtitle    string
+		postID      string
+		author      string
+		siteName    string
+		categories  []string
+		tags        []string
+		firstImage  string
+		createdAt   time.Time
+		updatedAt   time.Time
+		engagement  *models.Engagement
+		prevPost    string
+		nextPost    string
+		contains    []string
+		notContains []string
+	}{
+		{
+			file:        "n1234567890ab.html",
+			platform:    platformNote,
+			title:       "朝の散歩",
+			author:      "まっく",
+			siteName:    "note（ノート）",
+			tags:        []string{"日記", "散歩"},
+			firstImage:  "https://assets.st-note.com/production/uploads/images/eyecatch.jpg",
+			createdAt:   time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			contains:    []string{"帰りにパン屋に寄った。"},
+			notContains: []string{"有料部分", "記事を購入する", "300円", "スキ"},
+		},
+		{
+			file:       "walk.html",
+			platform:   platformWordPress,
+			title:      "朝の散歩",
+			postID:     "123",
+			author:     "まっく",
+			categories: []string{"diary", "散歩"},
+			tags:       []string{"walk", "パン"},
+			firstImage: "https://example.com/wp-content/uploads/2025/04/walk-1200x800.jpg",
+			createdAt:  time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			// Bloggerの.post-footerはWordPressの本文からは削除しない
+			contains:    []string{"帰りにパン屋に寄った。", "散歩のコースは毎回少しずつ変えている。"},
+			notContains: []string{"共有:", "いいね:", "読み込み中", "関連"},
+		},
+		{
+			file:        "blog-post.html",
+			platform:    platformBlogger,
+			title:       "朝の散歩",
+			postID:      "9876543210",
+			author:      "まっく",
+			tags:        []string{"日記", "散歩"},
+			createdAt:   time.Date(2025, 4, 13, 18, 18, 0, 0, time.UTC),
+			prevPost:    "https://mac-diary.blogspot.com/2025/04/blog-post_12.html",
+			nextPost:    "https://mac-diary.blogspot.com/2025/04/blog-post_14.html",
+			contains:    []string{"帰りにパン屋に寄った。"},
+			notContains: []string{"メールで送信", "編集", "ラベル"},
+		},
+		{
+			file:       "c686397e4a0f4f11683d.html",
+			platform:   platformQiita,
+			title:      "Goでテーブル駆動テストを書く",
+			postID:     "c686397e4a0f4f11683d",
+			author:     "まっく",
+			siteName:   "Qiita",
+			tags:       []string{"Go", "テスト"},
+			createdAt:  time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			updatedAt:  time.Date(2025, 5, 1, 9, 0, 0, 0, jst),
+			engagement: &models.Engagement{Likes: 42, Comments: 3},
+			contains: []string{
+				`<pre><code class="language-go" data-filename="add_test.go">for _, tt := range tests {`,
+				`<span class="math math-inline">$n$</span>`,
+				"<div class=\"math math-display\">$$\nO(n)\n$$</div>",
+			},
+			notContains: []string{"Copy"},
+		},
+		{
+			file:       "rust-ownership.html",
+			platform:   platformZenn,
+			title:      "Rustの所有権を図で理解する",
+			postID:     "rust-ownership",
+			author:     "まっく",
+			siteName:   "Zenn",
+			tags:       []string{"Rust", "初心者"},
+			createdAt:  time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			updatedAt:  time.Date(2025, 4, 20, 10, 0, 0, 0, jst),
+			engagement: &models.Engagement{Likes: 128},
+			contains: []string{
+				`<pre><code class="language-rust" data-filename="main.rs">let s = String::from(&#34;hello&#34;);</code></pre>`,
+				`<span class="math math-inline">$k$</span>`,
+				"<div class=\"math math-display\">$$\nk \\ge 0\n$$</div>",
+			},
+		},
+		{
+			file:        "understanding-go-interfaces-1a2b3c4d5e6f.html",
+			platform:    platformMedium,
+			title:       "Understanding Go Interfaces",
+			subtitle:    "Small interfaces make flexible programs",
+			postID:      "1a2b3c4d5e6f",
+			author:      "Mac",
+			siteName:    "Gopher Notes",
+			tags:        []string{"Golang", "Programming"},
+			createdAt:   time.UnixMilli(1744535885000),
+			updatedAt:   time.UnixMilli(1745000000000),
+			engagement:  &models.Engagement{Likes: 1234, Comments: 5},
+			contains:    []string{"Keeping interfaces small"},
+			notContains: []string{"Understanding Go Interfaces", "Member-only", "Gopher Notes", "Clap", "1.2K", "Small interfaces make"},
+		},
+		{
+			file:        "notes-on-slow-reading.html",
+			platform:    platformSubstack,
+			title:       "Notes on slow reading",
+			subtitle:    "Why I read fewer books this year",
+			postID:      "123456",
+			author:      "Hana",
+			siteName:    "The Weekly Shelf",
+			tags:        []string{"Books", "Reading"},
+			createdAt:   time.Date(2025, 4, 13, 18, 18, 5, 0, jst),
+			updatedAt:   time.Date(2025, 4, 14, 9, 0, 0, 0, jst),
+			engagement:  &models.Engagement{Likes: 87, Comments: 12, Shares: 3},
+			contains:    []string{"Reading slowly made it easier"},
+			notContains: []string{"Subscribe", "free trial"},
+		},
+		{
+			file:        "10123456.html",
+			platform:    platformExcite,
+			title:       "春の山歩き",
+			author:      "山歩きの記録",
+			siteName:    "山歩きの記録",
+			categories:  []string{"登山"},
+			tags:        []string{"桜", "温泉"},
+			createdAt:   time.Date(2010, 5, 1, 12, 34, 0, 0, jst),
+			engagement:  &models.Engagement{Shares: 2},
+			contains:    []string{"露天風呂から見える夕日"},
+			notContains: []string{"by ", "2010-05-01", "トラックバック"},
+		},
+		{
+			// 不明なページには、どのブログサービス固有の削除も適用しない
+			file:     "plain-entry.html",
+			platform: platformUnknown,
+			title:    "梅が咲いた",
+			contains: []string{
+				"白梅と紅梅が並んで咲いている。",
+				"咲き始めの枝を一本切って玄関に飾った。",
+				"来週は剪定をする予定だ。",
+				"肥料は寒肥を一月に与えた。",
+				"メジロが蜜を吸いに来ていた。",
+			},
+			notContains: []string{"前の記事"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("..", "sample", "test", "testdata", tt.file)
+			if got := detectPlatform(loadSampleDocument(t, path)); got != tt.platform {
+				t.Errorf("detectPlatform() = %q, want %q", got, tt.platform)
+			}
+			post, err := New().ParseFile(context.Background(), path)
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+
+			fields := []struct {
+				name      string
+				got, want string
+			}{
+				{"Title", post.Title, tt.title},
+				{"Subtitle", post.Subtitle, tt.subtitle},
+				{"PostID", post.PostID, tt.postID},
+				{"Author", post.Author, tt.author},
+				{"SiteName", post.SiteName, tt.siteName},
+				{"FirstImage", post.FirstImage, tt.firstImage},
+				{"PrevPost", postLinkURL(post.PrevPost), tt.prevPost},
+				{"NextPost", postLinkURL(post.NextPost), tt.nextPost},
+			}
+			for _, f := range fields {
+				if f.got != f.want {
+					t.Errorf("%s = %q, want %q", f.name, f.got, f.want)
+				}
+			}
+			if !reflect.DeepEqual(post.Categories, tt.categories) {
+				t.Errorf("Categories = %v, want %v", post.Categories, tt.categories)
+			}
+			if !reflect.DeepEqual(post.Tags, tt.tags) {
+				t.Errorf("Tags = %v, want %v", post.Tags, tt.tags)
+			}
+			if !post.CreatedAt.Equal(tt.createdAt) {
+				t.Errorf("CreatedAt = %v, want %v", post.CreatedAt, tt.createdAt)
+			}
+			if !post.UpdatedAt.Equal(tt.updatedAt) {
+				t.Errorf("UpdatedAt = %v, want %v", post.UpdatedAt, tt.updatedAt)
+			}
+			if !reflect.DeepEqual(post.Engagement, tt.engagement) {
+				t.Errorf("Engagement = %+v, want %+v", post.Engagement, tt.engagement)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(post.Content, want) {
+					t.Errorf("Content does not contain %q: %s", want, post.Content)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(post.Content, unwanted) {
+					t.Errorf("Content contains %q: %s", unwanted, post.Content)
+				}
+			}
+		})
+	}
+}
+
+// postLinkURL は前後の記事へのリンクのURLを返します（リンクがない場合は空文字列）
+func postLinkURL(link *models.PostLink) string {
+	if link == nil {
+		return ""
+	}
+	return link.URL
+}
+
+// loadSampleDocument はサンプルHTMLファイルを読み込みます
+func loadSampleDocument(t *testing.T, path string) *goquery.Document {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
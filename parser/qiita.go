@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 記事のURLの記事ID（https://qiita.com/user/items/c686397e4a0f4f11683d）
+var qiitaItemIDRe = regexp.MustCompile(`/items/([0-9a-f]+)`)
+
+// qiitaMeta はQiitaのページに埋め込まれた記事データ（script[type="application/json"]）からメタデータを取り出します。
+// 記事データはLGTMの数（likesCount）を持つオブジェクトとして探します。
+// 関連記事・おすすめ記事も同じ形式のため、URLから記事IDが分かる場合は記事IDが一致するものだけを対象にします。
+func qiitaMeta(doc *goquery.Document) articleMeta {
+	var meta articleMeta
+	var itemID string
+	if matches := qiitaItemIDRe.FindStringSubmatch(extractCanonicalURL(doc)); matches != nil {
+		itemID = matches[1]
+	}
+	doc.Find("script[type='application/json']").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var v any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(s.Text())), &v); err != nil {
+			return true
+		}
+		article := findJSONObject(v, func(obj map[string]any) bool {
+			if _, ok := obj["likesCount"]; !ok || jsonString(obj["title"]) == "" {
+				return false
+			}
+			return itemID == "" || jsonFirstString(obj, "encryptedId", "uuid") == itemID
+		})
+		if article == nil {
+			return true
+		}
+
+		meta.postID = jsonFirstString(article, "encryptedId", "uuid")
+		meta.title = jsonString(article["title"])
+		if t, err := parseDateString(jsonFirstString(article, "publishedAt", "createdAt")); err == nil {
+			meta.date = t
+		}
+		if t, err := parseDateString(jsonString(article["updatedAt"])); err == nil {
+			meta.updated = t
+		}
+		if author := jsonObject(article, "author"); author != nil {
+			meta.author = jsonFirstString(author, "name", "urlName")
+		}
+		meta.tags = jsonNames(article["tags"], "name")
+
+		meta.engagement = make(map[string]int)
+		if n, ok := jsonInt(article["likesCount"]); ok {
+			meta.engagement["likes"] = n
+		}
+		if n, ok := jsonInt(article["commentsCount"]); ok {
+			meta.engagement["comments"] = n
+		}
+		return false
+	})
+	return meta
+}
+
+// jsonNames はオブジェクトの配列から、キーを順に参照して得られた名前を重複なく返します
+func jsonNames(v any, keys ...string) []string {
+	items, _ := v.([]any)
+	var names []string
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if name := jsonFirstString(obj, keys...); name != "" && !containsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// documentBaseURL はドキュメントの基準URLを決定します。
+// ドキュメント自身のURLは以下の優先順位で決定します：
+// 1. 呼び出し元が指定したURL（WithBaseURL）
+// 2. link[rel=canonical]
+// 3. og:url
+// <base href>が存在する場合は、ドキュメントのURLを基準に解決したものを基準URLとします。
+// 絶対URLが得られない場合はnilを返します。
+func documentBaseURL(doc *goquery.Document, documentURL string) *url.URL {
+	var base *url.URL
+
+	candidates := []string{documentURL}
+	if doc != nil {
+		candidates = append(candidates,
+			doc.Find("link[rel='canonical']").AttrOr("href", ""),
+			doc.Find("meta[property='og:url']").AttrOr("content", ""),
+		)
+	}
+	for _, candidate := range candidates {
+		if u := parseAbsoluteURL(candidate); u != nil {
+			base = u
+			break
+		}
+	}
+
+	if doc == nil {
+		return base
+	}
+
+	// <base href>はドキュメントのURLに対して解決する
+	if href := strings.TrimSpace(doc.Find("base[href]").First().AttrOr("href", "")); href != "" {
+		ref, err := url.Parse(href)
+		if err != nil {
+			return base
+		}
+		if base != nil {
+			return base.ResolveReference(ref)
+		}
+		if ref.IsAbs() {
+			return ref
+		}
+	}
+
+	return base
+}
+
+// parseAbsoluteURL はスキームとホストを持つURLのみをパースして返します
+func parseAbsoluteURL(rawURL string) *url.URL {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return nil
+	}
+	return u
+}
+
+// resolveURL は基準URLに対して相対URLを絶対URLに変換します。
+// フラグメントのみのURLやスキームを持つURL（mailto:, data: など）はそのまま返します。
+func resolveURL(base *url.URL, rawURL string) string {
+	trimmed := strings.TrimSpace(rawURL)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return rawURL
+	}
+
+	ref, err := url.Parse(trimmed)
+	if err != nil || ref.Scheme != "" {
+		return rawURL
+	}
+
+	if base == nil {
+		// 基準URLがなくてもプロトコル相対URLはhttpsとして扱う
+		if strings.HasPrefix(trimmed, "//") {
+			return "https:" + trimmed
+		}
+		return rawURL
+	}
+
+	return base.ResolveReference(ref).String()
+}
+
+// resolveSrcset はsrcset属性内の各候補URLを絶対URLに変換します
+func resolveSrcset(base *url.URL, srcset string) string {
+	candidates := strings.Split(srcset, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = resolveURL(base, fields[0])
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// resolveURLs はドキュメント内のリンク・画像URLを絶対URLに変換します
+func resolveURLs(doc *goquery.Document, base *url.URL) {
+	for _, ua := range urlAttributes {
+		doc.Find(ua.tag + "[" + ua.attr + "]").Each(func(i int, s *goquery.Selection) {
+			rawURL, _ := s.Attr(ua.attr)
+			if resolved := resolveURL(base, rawURL); resolved != rawURL {
+				s.SetAttr(ua.attr, resolved)
+			}
+		})
+	}
+
+	doc.Find("img[srcset], source[srcset]").Each(func(i int, s *goquery.Selection) {
+		srcset, _ := s.Attr("srcset")
+		s.SetAttr("srcset", resolveSrcset(base, srcset))
+	})
+}
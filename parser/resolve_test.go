@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestDocumentBaseURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		html        string
+		documentURL string
+		want        string
+	}{
+		{
+			name: "canonicalを使用",
+			html: `<link rel="canonical" href="https://ameblo.jp/user/entry-1.html">` +
+				`<meta property="og:url" content="https://example.com/og.html">`,
+			want: "https://ameblo.jp/user/entry-1.html",
+		},
+		{
+			name: "og:urlを使用",
+			html: `<meta property="og:url" content="https://example.com/og.html">`,
+			want: "https://example.com/og.html",
+		},
+		{
+			name:        "呼び出し元のURLを優先",
+			html:        `<link rel="canonical" href="https://ameblo.jp/user/entry-1.html">`,
+			documentURL: "https://example.com/saved.html",
+			want:        "https://example.com/saved.html",
+		},
+		{
+			name: "base hrefはドキュメントURLに対して解決",
+			html: `<base href="/blog/"><link rel="canonical" href="https://example.com/archives/1.html">`,
+			want: "https://example.com/blog/",
+		},
+		{
+			name: "絶対URLのbase href",
+			html: `<base href="https://cdn.example.com/">`,
+			want: "https://cdn.example.com/",
+		},
+		{
+			name: "相対URLのcanonicalは無視",
+			html: `<link rel="canonical" href="/entry-1.html">`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if u := documentBaseURL(doc, tt.documentURL); u != nil {
+				got = u.String()
+			}
+			if got != tt.want {
+				t.Errorf("documentBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	base := parseAbsoluteURL("https://ameblo.jp/user/entry-1.html")
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"絶対パス", "/img/a.jpg", "https://ameblo.jp/img/a.jpg"},
+		{"相対パス", "entry-2.html", "https://ameblo.jp/user/entry-2.html"},
+		{"プロトコル相対", "//stat.ameba.jp/a.jpg", "https://stat.ameba.jp/a.jpg"},
+		{"絶対URL", "http://example.com/a.jpg", "http://example.com/a.jpg"},
+		{"フラグメントのみ", "#section", "#section"},
+		{"mailto", "mailto:a@example.com", "mailto:a@example.com"},
+		{"空文字列", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveURL(base, tt.in); got != tt.want {
+				t.Errorf("resolveURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if got := resolveURL(nil, "//stat.ameba.jp/a.jpg"); got != "https://stat.ameba.jp/a.jpg" {
+		t.Errorf("resolveURL(nil, protocol-relative) = %q", got)
+	}
+	if got := resolveURL(nil, "/img/a.jpg"); got != "/img/a.jpg" {
+		t.Errorf("resolveURL(nil, relative) = %q", got)
+	}
+}
+
+func TestResolveSrcset(t *testing.T) {
+	base := parseAbsoluteURL("https://example.com/posts/")
+	got := resolveSrcset(base, "a.jpg 1x, /b.jpg 2x")
+	want := "https://example.com/posts/a.jpg 1x, https://example.com/b.jpg 2x"
+	if got != want {
+		t.Errorf("resolveSrcset() = %q, want %q", got, want)
+	}
+}
+
+func TestCleanContentWithBaseURL(t *testing.T) {
+	p := New(WithBaseURL("https://example.com/blog/entry.html")).(*HTMLParser)
+	input := `<p><a href="other.html">次</a><a href="#top">上へ</a><img src="/img/a.jpg"></p>`
+	want := `<p><a href="https://example.com/blog/other.html">次</a><a href="#top">上へ</a>` +
+		`<img src="https://example.com/img/a.jpg"/></p>`
+
+	got, err := p.CleanContent(input)
+	if err != nil {
+		t.Fatalf("CleanContent() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CleanContent() = %v, want %v", got, want)
+	}
+}
+
+func TestParseResolvesRelativeURLs(t *testing.T) {
+	html := `<html><head><title>テスト</title>
+		<link rel="canonical" href="https://example.com/archives/1.html">
+		<meta property="og:image" content="/img/og.jpg">
+	</head><body><article><a href="2.html">次の記事</a>` + strings.Repeat("テスト本文", 50) + `</article></body></html>`
+
+	post, err := New().Parse(context.Background(), strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if post.FirstImage != "https://example.com/img/og.jpg" {
+		t.Errorf("FirstImage = %q", post.FirstImage)
+	}
+	if !strings.Contains(post.Content, `href="https://example.com/archives/2.html"`) {
+		t.Errorf("relative link was not resolved: %s", post.Content)
+	}
+}
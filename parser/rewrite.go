@@ -0,0 +1,65 @@
+package parser
+
+import "github.com/PuerkitoBio/goquery"
+
+// URLRef は書き換え対象となるURLとその出現箇所を表す構造体です
+type URLRef struct {
+	URL     string             // 元のURL
+	Tag     string             // 要素名（a, img など）
+	Attr    string             // 属性名（href, src など）
+	Element *goquery.Selection // URLを保持している要素
+}
+
+// URLRewriter は本文中のリンク・画像URLを書き換えるためのインターフェースです。
+type URLRewriter interface {
+	// RewriteURL は書き換え後のURLを返します。
+	// 書き換えない場合はref.URLをそのまま返します。
+	RewriteURL(ref URLRef) string
+}
+
+// URLRewriterFunc は関数をURLRewriterとして扱うためのアダプタです。
+type URLRewriterFunc func(ref URLRef) string
+
+// RewriteURL はf(ref)を呼び出します。
+func (f URLRewriterFunc) RewriteURL(ref URLRef) string {
+	return f(ref)
+}
+
+// URLを保持する要素と属性の組み合わせ
+var urlAttributes = []struct {
+	tag  string
+	attr string
+}{
+	{"a", "href"},
+	{"img", "src"},
+	{"img", "data-src"},
+	{"source", "src"},
+	{"video", "src"},
+	{"video", "poster"},
+	{"audio", "src"},
+}
+
+// rewriteURLs はドキュメント内のURL属性にURLRewriterを適用します
+func (p *HTMLParser) rewriteURLs(doc *goquery.Document) {
+	if p.urlRewriter == nil {
+		return
+	}
+
+	for _, ua := range urlAttributes {
+		doc.Find(ua.tag + "[" + ua.attr + "]").Each(func(i int, s *goquery.Selection) {
+			rawURL, _ := s.Attr(ua.attr)
+			if rawURL == "" {
+				return
+			}
+			ref := URLRef{
+				URL:     rawURL,
+				Tag:     ua.tag,
+				Attr:    ua.attr,
+				Element: s,
+			}
+			if rewritten := p.urlRewriter.RewriteURL(ref); rewritten != rawURL {
+				s.SetAttr(ua.attr, rewritten)
+			}
+		})
+	}
+}
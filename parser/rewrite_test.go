@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCleanContentWithURLRewriter(t *testing.T) {
+	rw := URLRewriterFunc(func(ref URLRef) string {
+		if ref.Tag == "img" {
+			return strings.Replace(ref.URL, "stat.ameba.jp", "cdn.example.com", 1)
+		}
+		return strings.Replace(ref.URL, "ameblo.jp/user", "example.com", 1)
+	})
+	p := New(WithURLRewriter(rw)).(*HTMLParser)
+
+	input := `<div><a href="https://ameblo.jp/user/entry-1.html">前回</a>` +
+		`<img src="https://stat.ameba.jp/user_images/a.jpg"></div>`
+	want := `<div><a href="https://example.com/entry-1.html">前回</a>` +
+		`<img src="https://cdn.example.com/user_images/a.jpg"/></div>`
+
+	got, err := p.CleanContent(input)
+	if err != nil {
+		t.Fatalf("CleanContent() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CleanContent() = %v, want %v", got, want)
+	}
+}
+
+func TestRewriteURLsContext(t *testing.T) {
+	var refs []URLRef
+	rw := URLRewriterFunc(func(ref URLRef) string {
+		refs = append(refs, ref)
+		return ref.URL
+	})
+	p := &HTMLParser{urlRewriter: rw}
+
+	input := `<p><a href="a.html">a</a><a>アンカーなし</a><img data-src="lazy.jpg" src="b.jpg"></p>`
+	if _, err := p.CleanContent(input); err != nil {
+		t.Fatalf("CleanContent() error = %v", err)
+	}
+
+	want := []struct{ tag, attr, url string }{
+		{"a", "href", "a.html"},
+		{"img", "src", "b.jpg"},
+		{"img", "data-src", "lazy.jpg"},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("rewriter called %d times, want %d", len(refs), len(want))
+	}
+	for i, w := range want {
+		if refs[i].Tag != w.tag || refs[i].Attr != w.attr || refs[i].URL != w.url {
+			t.Errorf("refs[%d] = %s[%s]=%s, want %s[%s]=%s",
+				i, refs[i].Tag, refs[i].Attr, refs[i].URL, w.tag, w.attr, w.url)
+		}
+		if refs[i].Element == nil || refs[i].Element.Length() != 1 {
+			t.Errorf("refs[%d] element is not set", i)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+// SlugStrategy はスラッグの生成方式です。
+type SlugStrategy int
+
+const (
+	// SlugRomaji はタイトルを形態素解析し、読みをヘボン式ローマ字に変換します。
+	SlugRomaji SlugStrategy = iota + 1
+	// SlugDateID は作成日と記事ID（URL末尾の数字）を組み合わせます。
+	SlugDateID
+	// SlugHash はURL（なければタイトルと作成日時）のハッシュを使用します。
+	SlugHash
+	// SlugUnicode はタイトルの文字をそのまま残し、パーセントエンコードします。
+	SlugUnicode
+)
+
+// ハッシュ方式のスラッグの長さ（16進数の桁数）
+const slugHashLength = 12
+
+var (
+	// スラッグに使用できない文字の連続
+	slugInvalidRe = regexp.MustCompile(`[^a-z0-9]+`)
+	// URL・ファイル名に含まれる数字の連続
+	slugDigitsRe = regexp.MustCompile(`\d+`)
+)
+
+// カタカナ2文字の拗音などのローマ字表記
+var kanaDigraphs = map[string]string{
+	"キャ": "kya", "キュ": "kyu", "キョ": "kyo",
+	"シャ": "sha", "シュ": "shu", "シェ": "she", "ショ": "sho",
+	"チャ": "cha", "チュ": "chu", "チェ": "che", "チョ": "cho",
+	"ニャ": "nya", "ニュ": "nyu", "ニョ": "nyo",
+	"ヒャ": "hya", "ヒュ": "hyu", "ヒョ": "hyo",
+	"ミャ": "mya", "ミュ": "myu", "ミョ": "myo",
+	"リャ": "rya", "リュ": "ryu", "リョ": "ryo",
+	"ギャ": "gya", "ギュ": "gyu", "ギョ": "gyo",
+	"ジャ": "ja", "ジュ": "ju", "ジェ": "je", "ジョ": "jo",
+	"ヂャ": "ja", "ヂュ": "ju", "ヂョ": "jo",
+	"ビャ": "bya", "ビュ": "byu", "ビョ": "byo",
+	"ピャ": "pya", "ピュ": "pyu", "ピョ": "pyo",
+	"ティ": "ti", "トゥ": "tu", "ディ": "di", "ドゥ": "du", "デュ": "dyu",
+	"ファ": "fa", "フィ": "fi", "フェ": "fe", "フォ": "fo", "フュ": "fyu",
+	"ウィ": "wi", "ウェ": "we", "ウォ": "wo",
+	"ヴァ": "va", "ヴィ": "vi", "ヴェ": "ve", "ヴォ": "vo",
+	"ツァ": "tsa", "ツィ": "tsi", "ツェ": "tse", "ツォ": "tso",
+}
+
+// カタカナ1文字のローマ字表記
+var kanaMonographs = map[rune]string{
+	'ア': "a", 'イ': "i", 'ウ': "u", 'エ': "e", 'オ': "o",
+	'カ': "ka", 'キ': "ki", 'ク': "ku", 'ケ': "ke", 'コ': "ko",
+	'サ': "sa", 'シ': "shi", 'ス': "su", 'セ': "se", 'ソ': "so",
+	'タ': "ta", 'チ': "chi", 'ツ': "tsu", 'テ': "te", 'ト': "to",
+	'ナ': "na", 'ニ': "ni", 'ヌ': "nu", 'ネ': "ne", 'ノ': "no",
+	'ハ': "ha", 'ヒ': "hi", 'フ': "fu", 'ヘ': "he", 'ホ': "ho",
+	'マ': "ma", 'ミ': "mi", 'ム': "mu", 'メ': "me", 'モ': "mo",
+	'ヤ': "ya", 'ユ': "yu", 'ヨ': "yo",
+	'ラ': "ra", 'リ': "ri", 'ル': "ru", 'レ': "re", 'ロ': "ro",
+	'ワ': "wa", 'ヰ': "i", 'ヱ': "e", 'ヲ': "o", 'ン': "n",
+	'ガ': "ga", 'ギ': "gi", 'グ': "gu", 'ゲ': "ge", 'ゴ': "go",
+	'ザ': "za", 'ジ': "ji", 'ズ': "zu", 'ゼ': "ze", 'ゾ': "zo",
+	'ダ': "da", 'ヂ': "ji", 'ヅ': "zu", 'デ': "de", 'ド': "do",
+	'バ': "ba", 'ビ': "bi", 'ブ': "bu", 'ベ': "be", 'ボ': "bo",
+	'パ': "pa", 'ピ': "pi", 'プ': "pu", 'ペ': "pe", 'ポ': "po",
+	'ヴ': "vu",
+	'ァ': "a", 'ィ': "i", 'ゥ': "u", 'ェ': "e", 'ォ': "o",
+	'ャ': "ya", 'ュ': "yu", 'ョ': "yo", 'ヮ': "wa",
+}
+
+// 助詞として読みが変わるもの（ヘボン式では発音どおりに表記する）
+var particleRomaji = map[string]string{
+	"は": "wa",
+	"へ": "e",
+	"を": "o",
+}
+
+// SlugGenerator は記事からスラッグを生成し、生成済みのスラッグと重複しないようにします。
+// 複数のgoroutineから同時に使用できます。
+type SlugGenerator struct {
+	strategy SlugStrategy
+
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+// NewSlugGenerator は指定した方式でスラッグを生成するSlugGeneratorを作成します。
+func NewSlugGenerator(strategy SlugStrategy) *SlugGenerator {
+	return &SlugGenerator{
+		strategy: strategy,
+		used:     make(map[string]bool),
+	}
+}
+
+// Generate は記事のスラッグを生成します。
+// 同じSlugGeneratorで生成済みのスラッグと重複する場合は「-2」「-3」のような連番を付与します。
+func (g *SlugGenerator) Generate(post *models.BlogPost) string {
+	slug := g.baseSlug(post)
+	if slug == "" {
+		slug = hashSlug(post)
+	}
+	return g.unique(slug)
+}
+
+// baseSlug は重複を考慮しないスラッグを生成します
+func (g *SlugGenerator) baseSlug(post *models.BlogPost) string {
+	switch g.strategy {
+	case SlugRomaji:
+		return romajiSlug(post.Title)
+	case SlugDateID:
+		return dateIDSlug(post)
+	case SlugHash:
+		return hashSlug(post)
+	case SlugUnicode:
+		return unicodeSlug(post.Title)
+	}
+	return ""
+}
+
+// unique は生成済みのスラッグと重複しないように連番を付与します
+func (g *SlugGenerator) unique(slug string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	candidate := slug
+	for n := 2; g.used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", slug, n)
+	}
+	g.used[candidate] = true
+	return candidate
+}
+
+// romajiSlug はタイトルの読みをヘボン式ローマ字に変換してスラッグにします
+func romajiSlug(title string) string {
+	t, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+	if err != nil {
+		return ""
+	}
+
+	var words []string
+	prevKatakana := false
+	for _, token := range t.Tokenize(title) {
+		pos := token.POS()
+		if len(pos) > 0 && pos[0] == "助詞" {
+			if romaji, ok := particleRomaji[token.Surface]; ok {
+				words = append(words, romaji)
+				prevKatakana = false
+				continue
+			}
+		}
+
+		reading, ok := token.Reading()
+		if !ok || reading == "" || reading == "*" {
+			// 辞書にない語（英数字など）は表層形を使用する
+			reading = token.Surface
+		}
+		romaji := katakanaToRomaji(hiraganaToKatakana(reading))
+
+		// 分割されたカタカナ語（ルー/ティーン など）は1語として連結する
+		katakana := isKatakana(token.Surface)
+		if katakana && prevKatakana && len(words) > 0 {
+			words[len(words)-1] += romaji
+		} else {
+			words = append(words, romaji)
+		}
+		prevKatakana = katakana
+	}
+
+	slug := strings.ToLower(strings.Join(words, "-"))
+	slug = slugInvalidRe.ReplaceAllString(slug, "-")
+	return strings.Trim(slug, "-")
+}
+
+// isKatakana は文字列がすべてカタカナ（長音符を含む）かどうかを判定します
+func isKatakana(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.Is(unicode.Katakana, r) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+// hiraganaToKatakana はひらがなをカタカナに変換します
+func hiraganaToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + ('ァ' - 'ぁ')
+		}
+		return r
+	}, s)
+}
+
+// katakanaToRomaji はカタカナをヘボン式ローマ字に変換します。
+// 長音符は省略し、促音は次の子音を重ねて表記します。かなで書かれた長音（ウ、イ）はそのまま残します。
+// カタカナ以外の文字はそのまま残します。
+func katakanaToRomaji(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	sokuon := false
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		var romaji string
+		if i+1 < len(runes) {
+			if digraph, ok := kanaDigraphs[string(runes[i:i+2])]; ok {
+				romaji = digraph
+				i++
+			}
+		}
+		if romaji == "" {
+			switch r {
+			case 'ッ':
+				sokuon = true
+				continue
+			case 'ー':
+				continue
+			}
+			mono, ok := kanaMonographs[r]
+			if !ok {
+				b.WriteRune(r)
+				sokuon = false
+				continue
+			}
+			romaji = mono
+		}
+
+		if sokuon {
+			// 促音: chはtch、それ以外は先頭の子音を重ねる
+			if strings.HasPrefix(romaji, "ch") {
+				b.WriteByte('t')
+			} else if c := romaji[0]; !strings.ContainsRune("aiueon", rune(c)) {
+				b.WriteByte(c)
+			}
+			sokuon = false
+		}
+		b.WriteString(romaji)
+	}
+	return b.String()
+}
+
+// dateIDSlug は作成日と記事IDからスラッグを生成します
+func dateIDSlug(post *models.BlogPost) string {
+	id := ""
+	for _, source := range []string{post.URL, post.CanonicalURL, post.Slug} {
+		if digits := slugDigitsRe.FindAllString(source, -1); len(digits) > 0 {
+			id = digits[len(digits)-1]
+			break
+		}
+	}
+	if id == "" {
+		id = hashSlug(post)
+	}
+
+	if post.CreatedAt.IsZero() {
+		return id
+	}
+	return post.CreatedAt.Format("20060102") + "-" + id
+}
+
+// hashSlug は記事URL（なければタイトルと作成日時）のハッシュからスラッグを生成します
+func hashSlug(post *models.BlogPost) string {
+	source := post.URL
+	if source == "" {
+		source = post.Title + "\n" + post.CreatedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	sum := sha1.Sum([]byte(source))
+	return hex.EncodeToString(sum[:])[:slugHashLength]
+}
+
+// unicodeSlug はタイトルの文字・数字を残し、パーセントエンコードしたスラッグを生成します
+func unicodeSlug(title string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r)
+	}) {
+		words = append(words, url.PathEscape(word))
+	}
+	return strings.Join(words, "-")
+}
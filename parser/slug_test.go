@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+func TestKatakanaToRomaji(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"ルーティーン", "rutin"},
+		{"ガッサン", "gassan"},
+		{"マッチャ", "matcha"},
+		{"キョウト", "kyouto"},
+		{"シンブン", "shinbun"},
+		{"ファイル", "fairu"},
+		{"Go", "Go"},
+	}
+	for _, c := range cases {
+		if got := katakanaToRomaji(c.in); got != c.want {
+			t.Errorf("katakanaToRomaji(%q)=%q want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSlugGeneratorStrategies(t *testing.T) {
+	tz := time.FixedZone("JST", 9*3600)
+	post := &models.BlogPost{
+		Title:     "月山に思いを馳せる満月の夜",
+		URL:       "https://kapparin.exblog.jp/16274503/",
+		CreatedAt: time.Date(2011, 9, 12, 23, 31, 0, 0, tz),
+	}
+
+	tests := []struct {
+		name     string
+		strategy SlugStrategy
+		post     *models.BlogPost
+		want     string
+	}{
+		{"ローマ字", SlugRomaji, post, "gassan-ni-omoi-o-haseru-mangetsu-no-yoru"},
+		{"ローマ字（記号と助詞）", SlugRomaji, &models.BlogPost{Title: "『ルーティーン』は大事"}, "rutin-wa-daiji"},
+		{"ローマ字（英数字）", SlugRomaji, &models.BlogPost{Title: "Go言語入門 2024"}, "go-gengo-nyuumon-2024"},
+		{"日付とID", SlugDateID, post, "20110912-16274503"},
+		{"日付なし", SlugDateID, &models.BlogPost{Slug: "entry-12887862927.html"}, "12887862927"},
+		{"ハッシュ", SlugHash, post, hashSlug(post)},
+		{"Unicode", SlugUnicode, &models.BlogPost{Title: "『ルーティーン』 2"}, "%E3%83%AB%E3%83%BC%E3%83%86%E3%82%A3%E3%83%BC%E3%83%B3-2"},
+		{"変換できない場合はハッシュ", SlugRomaji, &models.BlogPost{Title: "！！！"}, hashSlug(&models.BlogPost{Title: "！！！"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewSlugGenerator(tt.strategy).Generate(tt.post)
+			if got != tt.want {
+				t.Errorf("Generate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if len(hashSlug(post)) != slugHashLength {
+		t.Errorf("hashSlug length = %d, want %d", len(hashSlug(post)), slugHashLength)
+	}
+}
+
+func TestSlugGeneratorUnique(t *testing.T) {
+	g := NewSlugGenerator(SlugUnicode)
+	want := []string{"a", "a-2", "a-3"}
+	for _, w := range want {
+		if got := g.Generate(&models.BlogPost{Title: "A"}); got != w {
+			t.Errorf("Generate() = %q, want %q", got, w)
+		}
+	}
+	// 連番付きのスラッグと同じタイトルでも重複しない
+	if got := g.Generate(&models.BlogPost{Title: "a 2"}); got != "a-2-2" {
+		t.Errorf("Generate() = %q, want %q", got, "a-2-2")
+	}
+
+	// 並行に生成しても重複しない
+	g = NewSlugGenerator(SlugUnicode)
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slug := g.Generate(&models.BlogPost{Title: "same"})
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[slug] {
+				t.Errorf("duplicate slug %q", slug)
+			}
+			seen[slug] = true
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParseFileWithSlugStrategy(t *testing.T) {
+	p := New(WithSlugStrategy(SlugDateID))
+	ctx := context.Background()
+	file := filepath.Join("..", "sample", "test", "testdata", "12887862927.html")
+
+	want := []string{"20250413-12887862927", "20250413-12887862927-2"}
+	for _, w := range want {
+		post, err := p.ParseFile(ctx, file)
+		if err != nil {
+			t.Fatalf("ParseFile() error = %v", err)
+		}
+		if post.Slug != w {
+			t.Errorf("Slug = %q, want %q", post.Slug, w)
+		}
+	}
+}
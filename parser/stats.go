@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/yamadatt/blogparser/pkg/models"
+	"golang.org/x/net/html"
+)
+
+// 読了時間の算出に使用する1分あたりの文字数の既定値
+const defaultReadingSpeed = 500
+
+// 段落として扱うブロック要素
+var paragraphElements = map[string]bool{
+	"p": true, "div": true, "li": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"figure": true, "figcaption": true, "table": true, "tr": true, "dd": true, "dt": true,
+}
+
+// 空行（空白のみの行を含む）による段落の区切り
+var paragraphBreakRe = regexp.MustCompile(`\n[ \t\p{Zs}]*\n`)
+
+// ComputeStats は本文の文字数・単語数・文の数・段落の数・画像の数と読了時間の目安を算出します。
+// 文字数はHTMLタグと空白を除いた文字（ルーン）の数です。
+func (p *HTMLParser) ComputeStats(content string) (models.Stats, error) {
+	var stats models.Stats
+	if strings.TrimSpace(content) == "" {
+		return stats, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return stats, fmt.Errorf("HTMLのパース中にエラーが発生しました: %w", err)
+	}
+
+	// 埋め込みメディアのプレースホルダーは本文の文字として数えない
+	doc.Find(embedPlaceholderSelector).Remove()
+	doc.Find("script, style").Remove()
+
+	var b strings.Builder
+	for _, node := range doc.Find("body").Nodes {
+		writeBlockText(&b, node)
+	}
+	text := b.String()
+
+	paragraphs := paragraphBreakRe.Split(text, -1)
+	for _, paragraph := range paragraphs {
+		if !hasLetterOrDigit(paragraph) {
+			continue
+		}
+		stats.Paragraphs++
+		stats.Sentences += countSentences(paragraph)
+	}
+
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			stats.Characters++
+		}
+	}
+
+	stats.Words, err = countWords(text)
+	if err != nil {
+		return stats, err
+	}
+
+	stats.Images = doc.Find("img").Length()
+	stats.ReadingTimeSeconds = p.readingTime(stats.Characters)
+
+	return stats, nil
+}
+
+// writeBlockText は要素のテキストを書き出します。
+// ブロック要素の前後には空行を、brには改行を挿入します。
+func writeBlockText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		if n.Data == "br" {
+			b.WriteString("\n")
+			return
+		}
+	}
+
+	block := n.Type == html.ElementNode && paragraphElements[n.Data]
+	if block {
+		b.WriteString("\n\n")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeBlockText(b, c)
+	}
+	if block {
+		b.WriteString("\n\n")
+	}
+}
+
+// countSentences は段落内の文の数を数えます。
+// 句点・感嘆符・疑問符と改行を文の区切りとし、文字・数字を含まない断片は数えません。
+func countSentences(paragraph string) int {
+	count := 0
+	sentence := false
+	for _, r := range paragraph {
+		switch r {
+		case '。', '．', '！', '？', '!', '?', '\n':
+			if sentence {
+				count++
+			}
+			sentence = false
+		default:
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				sentence = true
+			}
+		}
+	}
+	if sentence {
+		count++
+	}
+	return count
+}
+
+// countWords は形態素解析により記号・空白を除いた単語の数を数えます
+func countWords(text string) (int, error) {
+	t, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrTokenizer, err)
+	}
+
+	count := 0
+	for _, token := range t.Tokenize(text) {
+		if !hasLetterOrDigit(token.Surface) {
+			continue
+		}
+		if pos := token.POS(); len(pos) > 0 && pos[0] == "記号" {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// readingTime は文字数から読了時間の目安の秒数を算出します（切り上げ）
+func (p *HTMLParser) readingTime(characters int) int {
+	speed := p.readingSpeed
+	if speed <= 0 {
+		speed = defaultReadingSpeed
+	}
+	return int(math.Ceil(float64(characters) * 60 / float64(speed)))
+}
+
+// hasLetterOrDigit は文字列に文字または数字が含まれるかどうかを判定します
+func hasLetterOrDigit(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		characters  int
+		sentences   int
+		paragraphs  int
+		images      int
+		readingTime int
+	}{
+		{
+			name:        "段落と文",
+			content:     `<p>今日は晴れ。散歩に行った！</p><p>楽しかった</p>`,
+			characters:  18,
+			sentences:   3,
+			paragraphs:  2,
+			readingTime: 3,
+		},
+		{
+			name:        "brによる段落",
+			content:     `<div>一行目<br>二行目<br><br>三行目</div>`,
+			characters:  9,
+			sentences:   3,
+			paragraphs:  2,
+			readingTime: 2,
+		},
+		{
+			name:        "画像と埋め込み",
+			content:     `<p>写真です。<img src="a.jpg"><img src="b.jpg"></p><figure class="embed embed-youtube" data-embed-provider="youtube" data-embed-id="x"><a href="https://youtu.be/x">https://youtu.be/x</a></figure>`,
+			characters:  5,
+			sentences:   1,
+			paragraphs:  1,
+			images:      2,
+			readingTime: 1,
+		},
+		{
+			name:    "空の本文",
+			content: "",
+		},
+	}
+
+	p := &HTMLParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := p.ComputeStats(tt.content)
+			if err != nil {
+				t.Fatalf("ComputeStats() error = %v", err)
+			}
+			if stats.Characters != tt.characters {
+				t.Errorf("Characters = %d, want %d", stats.Characters, tt.characters)
+			}
+			if stats.Sentences != tt.sentences {
+				t.Errorf("Sentences = %d, want %d", stats.Sentences, tt.sentences)
+			}
+			if stats.Paragraphs != tt.paragraphs {
+				t.Errorf("Paragraphs = %d, want %d", stats.Paragraphs, tt.paragraphs)
+			}
+			if stats.Images != tt.images {
+				t.Errorf("Images = %d, want %d", stats.Images, tt.images)
+			}
+			if stats.ReadingTimeSeconds != tt.readingTime {
+				t.Errorf("ReadingTimeSeconds = %d, want %d", stats.ReadingTimeSeconds, tt.readingTime)
+			}
+			if tt.characters > 0 && stats.Words == 0 {
+				t.Errorf("Words = 0, want > 0")
+			}
+		})
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	got, err := countWords("私は東京へ行きました。")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 私/は/東京/へ/行き/まし/た（句点は数えない）
+	if got != 7 {
+		t.Errorf("countWords() = %d, want 7", got)
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	tests := []struct {
+		speed      int
+		characters int
+		want       int
+	}{
+		{0, 1000, 120},
+		{400, 1000, 150},
+		{600, 1, 1},
+		{500, 0, 0},
+	}
+	for _, tt := range tests {
+		p := &HTMLParser{readingSpeed: tt.speed}
+		if got := p.readingTime(tt.characters); got != tt.want {
+			t.Errorf("readingTime(%d) with speed %d = %d, want %d", tt.characters, tt.speed, got, tt.want)
+		}
+	}
+}
+
+func TestParseFileStats(t *testing.T) {
+	path := filepath.Join("..", "sample", "test", "testdata", "12887862927.html")
+	post, err := New(WithReadingSpeed(300)).ParseFile(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := post.Stats
+	if stats.Characters == 0 || stats.Characters >= len(post.Content) {
+		t.Errorf("Characters = %d, want between 0 and byte length %d", stats.Characters, len(post.Content))
+	}
+	if stats.Words == 0 || stats.Sentences == 0 || stats.Paragraphs == 0 || stats.Images == 0 {
+		t.Errorf("Stats = %+v, want non-zero counts", stats)
+	}
+	want := (stats.Characters*60 + 299) / 300
+	if stats.ReadingTimeSeconds != want {
+		t.Errorf("ReadingTimeSeconds = %d, want %d", stats.ReadingTimeSeconds, want)
+	}
+}
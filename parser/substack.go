@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Substackのページに埋め込まれた初期データの変数名
+const substackPreloadsPrefix = "window._preloads"
+
+// substackMeta はSubstackの_preloadsから記事データを取り出します
+func substackMeta(doc *goquery.Document) articleMeta {
+	var meta articleMeta
+	preloads := scriptJSONValue(doc, substackPreloadsPrefix)
+	post := jsonObject(preloads, "post")
+	if post == nil {
+		return meta
+	}
+
+	meta.postID = jsonNumberString(post["id"])
+	meta.title = jsonString(post["title"])
+	meta.subtitle = jsonString(post["subtitle"])
+	if t, err := parseDateString(jsonString(post["post_date"])); err == nil {
+		meta.date = t
+	}
+	if t, err := parseDateString(jsonString(post["updated_at"])); err == nil {
+		meta.updated = t
+	}
+	if bylines := jsonNames(post["publishedBylines"], "name"); len(bylines) > 0 {
+		meta.author = bylines[0]
+	}
+	meta.siteName = jsonString(jsonObject(preloads, "pub")["name"])
+	meta.tags = jsonNames(post["postTags"], "name")
+	meta.image = jsonString(post["cover_image"])
+
+	meta.engagement = make(map[string]int)
+	if n, ok := jsonInt(post["reaction_count"]); ok {
+		meta.engagement["likes"] = n
+	}
+	if n, ok := jsonInt(post["comment_count"]); ok {
+		meta.engagement["comments"] = n
+	}
+	if n, ok := jsonInt(post["restacks"]); ok {
+		meta.engagement["shares"] = n
+	}
+	return meta
+}
@@ -9,16 +9,33 @@ import (
 )
 
 // extractTags はHTMLドキュメントからタグを抽出します。
-// 以下の優先順位で抽出を試みます：
+// プラットフォーム固有のマークアップ（articleMarkups）やclass属性などの記事データで見つかった場合はそれを優先し、
+// 見つからない場合は以下の優先順位で抽出を試みます：
 // 1. よく使われるタグ用セレクタ
 // 2. ld_blog_varsのarticles[0].tags
 // 3. meta[name="keywords"]
 // 4. .tag, .tags, .entry-tags, .post-tags など
-func extractTags(doc *goquery.Document) ([]string, error) {
+func extractTags(page *articlePage) ([]string, error) {
+	doc := page.doc
 	if doc == nil {
 		return nil, errors.New("ドキュメントがnilです")
 	}
 
+	if page.hasMarkup {
+		if tags := markupTexts(doc, page.markup.tags, cleanTag); len(tags) > 0 {
+			return tags, nil
+		}
+		var tags []string
+		for _, tag := range page.meta.tags {
+			if tag = cleanTag(tag); tag != "" && !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) > 0 {
+			return tags, nil
+		}
+	}
+
 	var tags []string
 
 	// 1. よく使われるタグ用セレクタ
@@ -33,7 +33,7 @@ func TestExtractTags(t *testing.T) {
 	if err != nil {
 		t.Fatalf("doc error: %v", err)
 	}
-	tags, err := extractTags(doc)
+	tags, err := extractTags(newArticlePage(doc))
 	if err != nil {
 		t.Fatalf("extractTags error: %v", err)
 	}
@@ -49,7 +49,7 @@ func TestExtractTags(t *testing.T) {
 }
 
 func TestExtractTagsNil(t *testing.T) {
-	if _, err := extractTags(nil); err == nil {
+	if _, err := extractTags(newArticlePage(nil)); err == nil {
 		t.Error("expected error with nil document")
 	}
 }
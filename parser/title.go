@@ -9,17 +9,28 @@ import (
 )
 
 // extractTitle はHTMLドキュメントからタイトルを抽出します。
-// 以下の優先順位で抽出を試みます：
+// プラットフォーム固有のマークアップ（articleMarkups）やscriptに埋め込まれた記事データで見つかった場合はそれを優先し、
+// 見つからない場合は以下の優先順位で抽出を試みます：
 // 1. ld_blog_varsのarticles[0].title
 // 2. og:titleメタタグの内容
 // 3. 最初のh1タグのテキスト
 // 4. titleタグのテキスト
 // 5. titleメタタグの内容
-func extractTitle(doc *goquery.Document) (string, error) {
+func extractTitle(page *articlePage) (string, error) {
+	doc := page.doc
 	if doc == nil {
 		return "", errors.New("ドキュメントがnilです")
 	}
 
+	if page.hasMarkup {
+		if title := markupText(doc, page.markup.title); title != "" {
+			return title, nil
+		}
+		if title := page.meta.title; title != "" {
+			return title, nil
+		}
+	}
+
 	// 1. ld_blog_varsからタイトルを抽出
 	var foundTitle string
 	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
@@ -107,3 +118,16 @@ func isValidTitle(title string) bool {
 
 	return true
 }
+
+// extractSubtitle はHTMLドキュメントからサブタイトル（副題）を抽出します。
+// サブタイトルの記法はブログサービスごとに異なるため、プラットフォーム固有のマークアップと記事データのみを参照します。
+// 見つからない場合は空文字列を返します。
+func extractSubtitle(page *articlePage) string {
+	if !page.hasMarkup {
+		return ""
+	}
+	if subtitle := markupText(page.doc, page.markup.subtitle); subtitle != "" {
+		return subtitle
+	}
+	return page.meta.subtitle
+}
@@ -56,7 +56,7 @@ func TestExtractTitle(t *testing.T) {
 		if err != nil {
 			t.Fatalf("case %d: %v", i, err)
 		}
-		got, err := extractTitle(doc)
+		got, err := extractTitle(newArticlePage(doc))
 		if err != nil {
 			t.Fatalf("case %d: extractTitle error: %v", i, err)
 		}
@@ -67,7 +67,7 @@ func TestExtractTitle(t *testing.T) {
 
 	// error when nothing found
 	doc, _ := goquery.NewDocumentFromReader(strings.NewReader(`<div></div>`))
-	if _, err := extractTitle(doc); err == nil {
+	if _, err := extractTitle(newArticlePage(doc)); err == nil {
 		t.Error("expected error when title not found")
 	}
 }
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// URLMapping はCSVで定義された旧URLと新URLの対応表です。
+// URLRewriterとしてWithURLRewriterに渡すことができます。
+//
+// CSVは1列目に旧URL、2列目に新URLを記述します。
+// 旧URLが「*」で終わる場合は前方一致で置換し、残りの部分は新URLの末尾に引き継がれます。
+// 「#」で始まる行はコメントとして無視され、
+// 1行目の旧URLに「/」が含まれない場合はヘッダー行とみなして読み飛ばします。
+//
+//	old,new
+//	https://ameblo.jp/akinakai/entry-12403291408.html,https://example.com/posts/customize/
+//	https://stat.ameba.jp/user_images/*,https://cdn.example.com/images/*
+type URLMapping struct {
+	exact    map[string]string
+	prefixes []urlPrefixRule
+}
+
+// urlPrefixRule は前方一致による置換ルールです
+type urlPrefixRule struct {
+	from string
+	to   string
+}
+
+// LoadURLMappingCSV はio.ReaderからCSV形式のURL対応表を読み込みます。
+func LoadURLMappingCSV(r io.Reader) (*URLMapping, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	m := &URLMapping{exact: make(map[string]string)}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrURLMapping, err)
+		}
+
+		from := strings.TrimSpace(record[0])
+		to := strings.TrimSpace(record[1])
+		if first && !strings.Contains(from, "/") {
+			continue // ヘッダー行
+		}
+		if from == "" || to == "" {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("%w: %d行目: URLが空です", ErrURLMapping, line)
+		}
+
+		if prefix, ok := strings.CutSuffix(from, "*"); ok {
+			m.prefixes = append(m.prefixes, urlPrefixRule{
+				from: mappingKey(prefix),
+				to:   strings.TrimSuffix(to, "*"),
+			})
+			continue
+		}
+		m.exact[mappingKey(from)] = to
+	}
+
+	// より具体的な（長い）プレフィックスを優先する
+	sort.SliceStable(m.prefixes, func(i, j int) bool {
+		return len(m.prefixes[i].from) > len(m.prefixes[j].from)
+	})
+
+	return m, nil
+}
+
+// LoadURLMappingFile はファイルパスからCSV形式のURL対応表を読み込みます。
+func LoadURLMappingFile(path string) (*URLMapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ファイル %s を開けません: %w", path, err)
+	}
+	defer f.Close()
+
+	m, err := LoadURLMappingCSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("ファイル %s の読み込みに失敗: %w", path, err)
+	}
+	return m, nil
+}
+
+// Lookup は旧URLに対応する新URLを返します。
+// 対応が見つからない場合はfalseを返します。
+func (m *URLMapping) Lookup(rawURL string) (string, bool) {
+	if m == nil || rawURL == "" {
+		return "", false
+	}
+
+	// フラグメントは対応表の照合から除外し、置換後に付け直す
+	target, fragment, hasFragment := strings.Cut(rawURL, "#")
+	if hasFragment {
+		fragment = "#" + fragment
+	}
+
+	key := mappingKey(target)
+	if to, ok := m.exact[key]; ok {
+		return to + fragment, true
+	}
+	for _, rule := range m.prefixes {
+		if rest, ok := strings.CutPrefix(key, rule.from); ok {
+			return rule.to + rest + fragment, true
+		}
+	}
+	return "", false
+}
+
+// RewriteURL は対応表に従ってURLを書き換えます。
+func (m *URLMapping) RewriteURL(ref URLRef) string {
+	if to, ok := m.Lookup(ref.URL); ok {
+		return to
+	}
+	return ref.URL
+}
+
+// mappingKey はhttp/httpsの違いを無視して照合するためのキーを生成します
+func mappingKey(rawURL string) string {
+	lower := strings.ToLower(rawURL)
+	for _, scheme := range []string{"https:", "http:"} {
+		if strings.HasPrefix(lower, scheme+"//") {
+			return rawURL[len(scheme):]
+		}
+	}
+	return rawURL
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testMappingCSV = `old,new
+# 記事URL
+https://ameblo.jp/akinakai/entry-12403291408.html,https://example.com/posts/customize/
+# 画像URL
+https://stat.ameba.jp/user_images/*,https://cdn.example.com/images/*
+https://stat.ameba.jp/user_images/20180907/*,https://cdn.example.com/2018/*
+`
+
+func TestLoadURLMappingCSV(t *testing.T) {
+	m, err := LoadURLMappingCSV(strings.NewReader(testMappingCSV))
+	if err != nil {
+		t.Fatalf("LoadURLMappingCSV() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"完全一致", "https://ameblo.jp/akinakai/entry-12403291408.html", "https://example.com/posts/customize/", true},
+		{"スキームの違いを無視", "http://ameblo.jp/akinakai/entry-12403291408.html", "https://example.com/posts/customize/", true},
+		{"フラグメントを維持", "https://ameblo.jp/akinakai/entry-12403291408.html#main", "https://example.com/posts/customize/#main", true},
+		{"前方一致", "https://stat.ameba.jp/user_images/20250412/13/a.jpg", "https://cdn.example.com/images/20250412/13/a.jpg", true},
+		{"長いプレフィックスを優先", "https://stat.ameba.jp/user_images/20180907/17/b.jpg", "https://cdn.example.com/2018/17/b.jpg", true},
+		{"プロトコル相対URL", "//stat.ameba.jp/user_images/c.jpg", "https://cdn.example.com/images/c.jpg", true},
+		{"対応なし", "https://example.org/", "", false},
+		{"空文字列", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := m.Lookup(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Lookup(%q) = (%q, %v), want (%q, %v)", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLoadURLMappingCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"列数不足", "https://ameblo.jp/a/entry-1.html\n"},
+		{"列数過多", "https://ameblo.jp/a/entry-1.html,https://example.com/,extra\n"},
+		{"空のURL", "https://ameblo.jp/a/entry-1.html,\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadURLMappingCSV(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrURLMapping) {
+				t.Errorf("LoadURLMappingCSV() error = %v, want ErrURLMapping", err)
+			}
+		})
+	}
+}
+
+func TestURLMappingAsRewriter(t *testing.T) {
+	m, err := LoadURLMappingCSV(strings.NewReader(testMappingCSV))
+	if err != nil {
+		t.Fatalf("LoadURLMappingCSV() error = %v", err)
+	}
+	p := New(WithURLRewriter(m)).(*HTMLParser)
+
+	input := `<p><a href="https://ameblo.jp/akinakai/entry-12403291408.html">講座</a>` +
+		`<a href="https://example.org/">外部</a></p>`
+	want := `<p><a href="https://example.com/posts/customize/">講座</a>` +
+		`<a href="https://example.org/">外部</a></p>`
+
+	got, err := p.CleanContent(input)
+	if err != nil {
+		t.Fatalf("CleanContent() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CleanContent() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// WordPressの記事要素（post_class()の出力）のセレクタ（記述した順に優先する）
+var wordpressPostSelectors = []string{"article.type-post", "article.type-page", ".type-post", ".type-page", "article.hentry", ".hentry"}
+
+var (
+	// body・記事要素のclass属性の記事ID（postid-123、post-123）
+	wordpressBodyPostIDRe    = regexp.MustCompile(`(?:^|\s)postid-(\d+)(?:\s|$)`)
+	wordpressArticlePostIDRe = regexp.MustCompile(`(?:^|\s)post-(\d+)(?:\s|$)`)
+	// 短縮URL（?p=123）の記事ID
+	wordpressShortlinkRe = regexp.MustCompile(`[?&]p=(\d+)`)
+)
+
+// WordPressの初期カテゴリ（表示名ではなくスラッグしか得られないため除外する）
+const wordpressDefaultCategory = "uncategorized"
+
+// wordpressMeta はWordPressのclass属性や短縮URLから記事ID・カテゴリ・タグを取り出します。
+// カテゴリ・タグはclass属性のcategory-スラッグ・tag-スラッグを使用します。
+func wordpressMeta(doc *goquery.Document) articleMeta {
+	var meta articleMeta
+
+	var post *goquery.Selection
+	for _, sel := range wordpressPostSelectors {
+		if post = doc.Find(sel).First(); post.Length() > 0 {
+			break
+		}
+	}
+	classes := post.AttrOr("class", "")
+
+	if matches := wordpressBodyPostIDRe.FindStringSubmatch(doc.Find("body").AttrOr("class", "")); len(matches) > 1 {
+		meta.postID = matches[1]
+	} else if matches := wordpressArticlePostIDRe.FindStringSubmatch(classes); len(matches) > 1 {
+		meta.postID = matches[1]
+	} else if matches := wordpressArticlePostIDRe.FindStringSubmatch(post.AttrOr("id", "")); len(matches) > 1 {
+		meta.postID = matches[1]
+	} else if matches := wordpressShortlinkRe.FindStringSubmatch(doc.Find("link[rel='shortlink']").AttrOr("href", "")); len(matches) > 1 {
+		meta.postID = matches[1]
+	}
+
+	for _, class := range strings.Fields(classes) {
+		if slug, ok := strings.CutPrefix(class, "category-"); ok && slug != wordpressDefaultCategory {
+			if name := wordpressSlugName(slug); name != "" && !containsString(meta.categories, name) {
+				meta.categories = append(meta.categories, name)
+			}
+		} else if slug, ok := strings.CutPrefix(class, "tag-"); ok {
+			if name := wordpressSlugName(slug); name != "" && !containsString(meta.tags, name) {
+				meta.tags = append(meta.tags, name)
+			}
+		}
+	}
+	return meta
+}
+
+// wordpressSlugName はclass属性のスラッグを表示用の名前に戻します。
+// 日本語のスラッグはパーセントエンコードの%が除かれた16進数になるため、UTF-8として復元できる部分を戻します。
+func wordpressSlugName(slug string) string {
+	if unescaped, err := url.PathUnescape(slug); err == nil {
+		slug = unescaped
+	}
+	parts := strings.Split(slug, "-")
+	for i, part := range parts {
+		if len(part) < 6 || len(part)%2 != 0 {
+			continue
+		}
+		decoded, err := hex.DecodeString(part)
+		if err != nil || !utf8.Valid(decoded) {
+			continue
+		}
+		if s := string(decoded); strings.IndexFunc(s, func(r rune) bool { return r < utf8.RuneSelf }) == -1 {
+			parts[i] = s
+		}
+	}
+	return strings.Join(parts, "-")
+}
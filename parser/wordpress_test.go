@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestWordPressMeta(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want articleMeta
+	}{
+		{
+			name: "bodyのpostid",
+			html: `<body class="single postid-42"><article class="post-7 type-post category-news"></article></body>`,
+			want: articleMeta{postID: "42", categories: []string{"news"}},
+		},
+		{
+			name: "記事要素のid",
+			html: `<div id="post-7" class="hentry tag-go tag-go"></div>`,
+			want: articleMeta{postID: "7", tags: []string{"go"}},
+		},
+		{
+			name: "短縮URL",
+			html: `<link rel="shortlink" href="https://example.com/?p=5"><div class="entry-content">本文</div>`,
+			want: articleMeta{postID: "5"},
+		},
+		{
+			name: "記事要素なし",
+			html: `<p>本文</p>`,
+			want: articleMeta{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := wordpressMeta(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wordpressMeta() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordPressSlugName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"diary", "diary"},
+		{"e697a5e8a898", "日記"},
+		{"%e6%97%a5%e8%a8%98", "日記"},
+		{"go-e585a5e99680", "go-入門"},
+		{"cafe", "cafe"},
+		{"deadbeef", "deadbeef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := wordpressSlugName(tt.in); got != tt.want {
+				t.Errorf("wordpressSlugName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,424 @@
+package parser
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+	"golang.org/x/net/html/charset"
+)
+
+// WXRの日時フォーマット
+const wxrDateLayout = "2006-01-02 15:04:05"
+
+var (
+	// 空行による段落の区切り
+	wxrParagraphRe = regexp.MustCompile(`\n\s*\n`)
+	// 段落で囲まないブロック要素・ブロックエディタのコメントで始まる段落
+	wxrBlockRe = regexp.MustCompile(`(?i)^<(?:!--|/?(?:p|div|h[1-6]|ul|ol|li|dl|blockquote|pre|table|figure|hr|section|address|iframe|form|script|style)\b)`)
+	// 空行を含んでも分割しない複数行のブロック要素の開始・終了タグ
+	wxrProtectedTagRe = regexp.MustCompile(`(?i)<(/?)(pre|table|ul|ol|dl|blockquote|figure|script|style)\b[^>]*>`)
+)
+
+// 保護したブロック要素を段落の分割後に戻すためのプレースホルダー
+const wxrPlaceholderFormat = "<!--wpautop:%d-->"
+
+// インポートする投稿タイプ（添付ファイル・メニューなどは除外する）
+var wxrPostTypes = map[string]bool{"post": true, "page": true}
+
+// WXRParser はWordPressのエクスポートファイル（WXR）からブログ記事を解析するパーサーです。
+type WXRParser struct {
+	html *HTMLParser
+}
+
+// NewWXRParser は新しいWXRParserを作成します。
+// 各記事の本文はHTMLParserと同じオプションでクリーニング・要約されます。
+func NewWXRParser(opts ...Option) *WXRParser {
+	return &WXRParser{html: newHTMLParser(opts)}
+}
+
+// Parse はio.ReaderからWXRを読み込み、投稿と固定ページをBlogPostに変換します。
+// ファイル全体を読み込まず、item要素を1件ずつデコードします。
+// 公開状態（wp:status）がpublishの記事はPublishedがtrueになります。
+// アイキャッチ画像（_thumbnail_id）は、本文に画像がない場合のFirstImageとして使用します。
+func (w *WXRParser) Parse(ctx context.Context, r io.Reader) ([]*models.BlogPost, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charset.NewReaderLabel
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	var (
+		posts       []*models.BlogPost
+		thumbnails  = make(map[*models.BlogPost]string) // 記事とアイキャッチ画像の添付ファイルID
+		attachments = make(map[string]string)           // 添付ファイルIDとURL
+		authors     = make(map[string]string)           // ログイン名と表示名
+		siteName    string
+		location    = jstLocation // サイトのタイムゾーン（下書きの日時の解釈に使う）
+		path        []string
+		isWXR       bool
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("WXRのXMLのパースに失敗しました: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(path) == 0 && t.Name.Local != "rss" {
+				return nil, fmt.Errorf("%w: %s", ErrUnsupportedFeed, t.Name.Local)
+			}
+			if len(path) != 2 || path[1] != "channel" {
+				path = append(path, t.Name.Local)
+				continue
+			}
+
+			// channel直下の要素
+			switch t.Name.Local {
+			case "wxr_version":
+				isWXR = true
+			case "title":
+				var title string
+				if err := dec.DecodeElement(&title, &t); err != nil {
+					return nil, fmt.Errorf("WXRのXMLのパースに失敗しました: %w", err)
+				}
+				siteName = strings.TrimSpace(title)
+				continue
+			case "author":
+				var author wxrAuthor
+				if err := dec.DecodeElement(&author, &t); err != nil {
+					return nil, fmt.Errorf("WXRのXMLのパースに失敗しました: %w", err)
+				}
+				if author.DisplayName != "" {
+					authors[author.Login] = author.DisplayName
+				}
+				continue
+			case "item":
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				var item wxrItem
+				if err := dec.DecodeElement(&item, &t); err != nil {
+					return nil, fmt.Errorf("WXRのXMLのパースに失敗しました: %w", err)
+				}
+				if item.PostType == "attachment" {
+					attachments[item.PostID] = strings.TrimSpace(item.AttachmentURL)
+					continue
+				}
+				if !wxrPostTypes[item.PostType] {
+					continue
+				}
+				location = item.location(location)
+				post, err := w.parseItem(item, location)
+				if err != nil {
+					return nil, fmt.Errorf("記事「%s」の解析に失敗しました: %w", item.Title, err)
+				}
+				if id := item.meta("_thumbnail_id"); id != "" {
+					thumbnails[post] = id
+				}
+				posts = append(posts, post)
+				continue
+			}
+			path = append(path, t.Name.Local)
+		case xml.EndElement:
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+	}
+
+	if !isWXR && len(posts) == 0 {
+		return nil, fmt.Errorf("%w: WXRのバージョン（wp:wxr_version）がありません", ErrUnsupportedFeed)
+	}
+
+	for _, post := range posts {
+		post.SiteName = siteName
+		if name, ok := authors[post.Author]; ok {
+			post.Author = name
+		}
+		if post.FirstImage == "" {
+			post.FirstImage = normalizeImageURL(attachments[thumbnails[post]])
+		}
+		if w.html.slugs != nil {
+			post.Slug = w.html.slugs.Generate(post)
+		}
+	}
+	return posts, nil
+}
+
+// parseItem はWXRの1件の記事をBlogPostに変換します。locはサイトのタイムゾーンです。
+func (w *WXRParser) parseItem(item wxrItem, loc *time.Location) (*models.BlogPost, error) {
+	post := &models.BlogPost{
+		Title:     cleanTitle(item.Title),
+		Author:    strings.TrimSpace(item.Creator),
+		CreatedAt: wxrDate(item.PostDateGMT, item.PostDate, loc),
+		UpdatedAt: wxrDate(item.PostModifiedGMT, item.PostModified, loc),
+		Published: item.Status == "publish",
+		Slug:      strings.TrimSpace(item.PostName),
+		PostID:    strings.TrimSpace(item.PostID),
+		URL:       strings.TrimSpace(item.Link),
+		Comments:  item.comments(loc),
+	}
+
+	for _, c := range item.Categories {
+		name := strings.TrimSpace(c.Name)
+		switch c.Domain {
+		case "category":
+			if name = cleanCategory(name); isValidCategory(name) && !containsString(post.Categories, name) {
+				post.Categories = append(post.Categories, name)
+			}
+		case "post_tag":
+			if name != "" && !containsString(post.Tags, name) {
+				post.Tags = append(post.Tags, name)
+			}
+		}
+	}
+
+	if err := w.html.applyContent(post, wpautop(item.Content), parseAbsoluteURL(post.URL), platformWordPress); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// wxrDate はGMTの日時（なければlocのタイムゾーンの日時）をtime.Timeに変換します。
+// 下書きのGMTの日時は「0000-00-00 00:00:00」のため、サイトのタイムゾーンの日時を使用します。
+func wxrDate(gmt, local string, loc *time.Location) time.Time {
+	if t, err := time.Parse(wxrDateLayout, strings.TrimSpace(gmt)); err == nil {
+		return t
+	}
+	if t, err := time.ParseInLocation(wxrDateLayout, strings.TrimSpace(local), loc); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// wxrOffset はGMTとサイトのタイムゾーンの日時の組からタイムゾーンを求めます（求められない場合はnil）
+func wxrOffset(gmt, local string) *time.Location {
+	g, err := time.Parse(wxrDateLayout, strings.TrimSpace(gmt))
+	if err != nil {
+		return nil
+	}
+	l, err := time.Parse(wxrDateLayout, strings.TrimSpace(local))
+	if err != nil {
+		return nil
+	}
+	offset := l.Sub(g)
+	if offset%time.Minute != 0 || offset < -14*time.Hour || offset > 14*time.Hour {
+		return nil
+	}
+	if offset == 9*time.Hour {
+		return jstLocation
+	}
+	return time.FixedZone("", int(offset/time.Second))
+}
+
+// wpautop はWordPressのwpautopと同様に、空行で区切られたテキストを段落（p要素）に、
+// 段落内の改行をbr要素に変換します。ブロック要素で始まる部分はそのまま残します。
+// pre・table・ulなどの要素は中に空行があっても分割せず、中身もそのまま残します。
+func wpautop(content string) string {
+	content = strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\r", "\n")
+	if strings.TrimSpace(content) == "" {
+		return ""
+	}
+	content, blocks := protectBlocks(content)
+
+	var b strings.Builder
+	for _, block := range wxrParagraphRe.Split(content, -1) {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		if wxrBlockRe.MatchString(block) {
+			b.WriteString(block)
+		} else {
+			b.WriteString("<p>" + strings.ReplaceAll(block, "\n", "<br />\n") + "</p>")
+		}
+		b.WriteString("\n")
+	}
+
+	result := b.String()
+	for i, block := range blocks {
+		result = strings.Replace(result, fmt.Sprintf(wxrPlaceholderFormat, i), block, 1)
+	}
+	return result
+}
+
+// protectBlocks は複数行のブロック要素（入れ子を含む最も外側の要素）をプレースホルダーに置き換え、
+// 置き換えた要素を返します。終了タグのない要素はそのまま残します。
+func protectBlocks(content string) (string, []string) {
+	var (
+		b      strings.Builder
+		blocks []string
+	)
+	tags := wxrProtectedTagRe.FindAllStringSubmatchIndex(content, -1)
+	pos := 0
+	for i := 0; i < len(tags); i++ {
+		open := tags[i]
+		if open[2] != open[3] || open[0] < pos {
+			continue
+		}
+		name := strings.ToLower(content[open[4]:open[5]])
+		depth, end := 0, -1
+		for j := i; j < len(tags); j++ {
+			if !strings.EqualFold(content[tags[j][4]:tags[j][5]], name) {
+				continue
+			}
+			if tags[j][2] == tags[j][3] {
+				depth++
+				continue
+			}
+			if depth--; depth == 0 {
+				end, i = tags[j][1], j
+				break
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		b.WriteString(content[pos:open[0]])
+		fmt.Fprintf(&b, wxrPlaceholderFormat, len(blocks))
+		blocks = append(blocks, content[open[0]:end])
+		pos = end
+	}
+	b.WriteString(content[pos:])
+	return b.String(), blocks
+}
+
+// wxrAuthor はWXRのwp:author要素です
+type wxrAuthor struct {
+	Login       string `xml:"author_login"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+// wxrItem はWXRのitem要素です（WXRのバージョンによって名前空間が異なるため、wp:の要素はローカル名で照合する）
+type wxrItem struct {
+	Title           string            `xml:"title"`
+	Link            string            `xml:"link"`
+	Creator         string            `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Content         string            `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostID          string            `xml:"post_id"`
+	PostDate        string            `xml:"post_date"`
+	PostDateGMT     string            `xml:"post_date_gmt"`
+	PostModified    string            `xml:"post_modified"`
+	PostModifiedGMT string            `xml:"post_modified_gmt"`
+	PostName        string            `xml:"post_name"`
+	Status          string            `xml:"status"`
+	PostType        string            `xml:"post_type"`
+	AttachmentURL   string            `xml:"attachment_url"`
+	Categories      []wxrItemCategory `xml:"category"`
+	PostMeta        []wxrPostMeta     `xml:"postmeta"`
+	Comments        []wxrComment      `xml:"comment"`
+}
+
+type wxrItemCategory struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type wxrPostMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+type wxrComment struct {
+	ID        string `xml:"comment_id"`
+	Author    string `xml:"comment_author"`
+	AuthorURL string `xml:"comment_author_url"`
+	Date      string `xml:"comment_date"`
+	DateGMT   string `xml:"comment_date_gmt"`
+	Content   string `xml:"comment_content"`
+	Approved  string `xml:"comment_approved"`
+	Type      string `xml:"comment_type"`
+	Parent    string `xml:"comment_parent"`
+}
+
+// meta はカスタムフィールドの値を返します
+func (item wxrItem) meta(key string) string {
+	for _, m := range item.PostMeta {
+		if m.Key == key {
+			return strings.TrimSpace(m.Value)
+		}
+	}
+	return ""
+}
+
+// location はサイトのタイムゾーンを返します。
+// WXRにはタイムゾーンがないため、記事・コメントのGMTとサイトのタイムゾーンの日時の差から求め、
+// 求められない場合はdef（それまでの記事から求めたもの、なければMTParserと同じ日本時間）を返します。
+func (item wxrItem) location(def *time.Location) *time.Location {
+	pairs := [][2]string{{item.PostDateGMT, item.PostDate}, {item.PostModifiedGMT, item.PostModified}}
+	for _, c := range item.Comments {
+		pairs = append(pairs, [2]string{c.DateGMT, c.Date})
+	}
+	for _, p := range pairs {
+		if loc := wxrOffset(p[0], p[1]); loc != nil {
+			return loc
+		}
+	}
+	return def
+}
+
+// comments は承認済みのコメントを返信の入れ子にして返します（ピンバック・トラックバックは除外する）。
+// locはGMTの日時がないコメントに使うサイトのタイムゾーンです。
+func (item wxrItem) comments(loc *time.Location) []models.Comment {
+	type node struct {
+		comment models.Comment
+		parent  string
+		replies []string
+	}
+	nodes := make(map[string]*node)
+	var order []string
+	for _, c := range item.Comments {
+		if c.Approved != "1" || (c.Type != "" && c.Type != "comment") {
+			continue
+		}
+		nodes[c.ID] = &node{
+			comment: models.Comment{
+				Author:    strings.TrimSpace(c.Author),
+				AuthorURL: strings.TrimSpace(c.AuthorURL),
+				Body:      htmlCommentBody(c.Content),
+				CreatedAt: wxrDate(c.DateGMT, c.Date, loc),
+			},
+			parent: c.Parent,
+		}
+		order = append(order, c.ID)
+	}
+
+	var roots []string
+	for _, id := range order {
+		n := nodes[id]
+		if parent, ok := nodes[n.parent]; ok && n.parent != id {
+			parent.replies = append(parent.replies, id)
+		} else {
+			roots = append(roots, id)
+		}
+	}
+
+	// 親子関係が循環しているコメントはどの親からも辿れないため含まれない
+	var build func(ids []string) []models.Comment
+	build = func(ids []string) []models.Comment {
+		var result []models.Comment
+		for _, id := range ids {
+			c := nodes[id].comment
+			c.Replies = build(nodes[id].replies)
+			result = append(result, c)
+		}
+		return result
+	}
+	return build(roots)
+}
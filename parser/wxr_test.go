@@ -0,0 +1,274 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamadatt/blogparser/pkg/models"
+)
+
+const testWXR = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:wfw="http://wellformedweb.org/CommentAPI/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>アルツフルデイズ</title>
+	<link>https://example.com</link>
+	<wp:wxr_version>1.2</wp:wxr_version>
+	<wp:author>
+		<wp:author_id>1</wp:author_id>
+		<wp:author_login><![CDATA[author1]]></wp:author_login>
+		<wp:author_display_name><![CDATA[まっく]]></wp:author_display_name>
+	</wp:author>
+	<item>
+		<title>『ルーティーン』</title>
+		<link>https://example.com/2025/04/13/routine/</link>
+		<dc:creator><![CDATA[author1]]></dc:creator>
+		<content:encoded><![CDATA[今日も朝から散歩に行った。
+帰りにパン屋に寄った。
+
+<h2>午後</h2>
+
+午後は昼寝をした。]]></content:encoded>
+		<excerpt:encoded><![CDATA[抜粋]]></excerpt:encoded>
+		<wp:post_id>1</wp:post_id>
+		<wp:post_date><![CDATA[2025-04-13 18:18:05]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2025-04-13 09:18:05]]></wp:post_date_gmt>
+		<wp:post_modified_gmt><![CDATA[2025-04-14 00:00:00]]></wp:post_modified_gmt>
+		<wp:post_name><![CDATA[routine]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<category domain="category" nicename="blog"><![CDATA[ブログ]]></category>
+		<category domain="post_tag" nicename="care"><![CDATA[認知症]]></category>
+		<category domain="post_tag" nicename="care"><![CDATA[認知症]]></category>
+		<wp:postmeta>
+			<wp:meta_key><![CDATA[_thumbnail_id]]></wp:meta_key>
+			<wp:meta_value><![CDATA[3]]></wp:meta_value>
+		</wp:postmeta>
+		<wp:comment>
+			<wp:comment_id>10</wp:comment_id>
+			<wp:comment_author><![CDATA[読者]]></wp:comment_author>
+			<wp:comment_author_url>https://reader.example.com/</wp:comment_author_url>
+			<wp:comment_date_gmt><![CDATA[2025-04-14 00:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[こんにちは
+<strong>応援</strong>しています]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[comment]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>11</wp:comment_id>
+			<wp:comment_author><![CDATA[まっく]]></wp:comment_author>
+			<wp:comment_content><![CDATA[ありがとう]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[]]></wp:comment_type>
+			<wp:comment_parent>10</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>12</wp:comment_id>
+			<wp:comment_author><![CDATA[スパム]]></wp:comment_author>
+			<wp:comment_content><![CDATA[広告]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[spam]]></wp:comment_approved>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>13</wp:comment_id>
+			<wp:comment_author><![CDATA[他のブログ]]></wp:comment_author>
+			<wp:comment_content><![CDATA[ピンバック]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[pingback]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+	</item>
+	<item>
+		<title>下書き</title>
+		<link>https://example.com/?p=2</link>
+		<dc:creator><![CDATA[guest]]></dc:creator>
+		<content:encoded><![CDATA[<!-- wp:paragraph -->
+<p>書きかけの記事です。</p>
+<!-- /wp:paragraph -->]]></content:encoded>
+		<wp:post_id>2</wp:post_id>
+		<wp:post_date><![CDATA[2025-04-20 10:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+	<item>
+		<title>o1.jpg</title>
+		<wp:post_id>3</wp:post_id>
+		<wp:post_type><![CDATA[attachment]]></wp:post_type>
+		<wp:attachment_url><![CDATA[https://example.com/wp-content/uploads/2025/04/o1.jpg]]></wp:attachment_url>
+	</item>
+	<item>
+		<title>メニュー</title>
+		<wp:post_id>4</wp:post_id>
+		<wp:post_type><![CDATA[nav_menu_item]]></wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+func TestWXRParserParse(t *testing.T) {
+	posts, err := NewWXRParser().Parse(context.Background(), strings.NewReader(testWXR))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("len(posts) = %d, want 2", len(posts))
+	}
+
+	post := posts[0]
+	checks := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"Title", post.Title, "『ルーティーン』"},
+		{"Author", post.Author, "まっく"},
+		{"URL", post.URL, "https://example.com/2025/04/13/routine/"},
+		{"Slug", post.Slug, "routine"},
+		{"PostID", post.PostID, "1"},
+		{"SiteName", post.SiteName, "アルツフルデイズ"},
+		{"Published", post.Published, true},
+		{"CreatedAt", post.CreatedAt, time.Date(2025, 4, 13, 9, 18, 5, 0, time.UTC)},
+		{"UpdatedAt", post.UpdatedAt, time.Date(2025, 4, 14, 0, 0, 0, 0, time.UTC)},
+		{"Categories", post.Categories, []string{"ブログ"}},
+		{"Tags", post.Tags, []string{"認知症"}},
+		{"FirstImage", post.FirstImage, "https://example.com/wp-content/uploads/2025/04/o1.jpg"},
+		{"Outline", len(post.Outline), 1},
+		{"Comments", post.Comments, []models.Comment{
+			{
+				Author:    "読者",
+				AuthorURL: "https://reader.example.com/",
+				Body:      "こんにちは\n応援しています",
+				CreatedAt: time.Date(2025, 4, 14, 0, 0, 0, 0, time.UTC),
+				Replies:   []models.Comment{{Author: "まっく", Body: "ありがとう"}},
+			},
+		}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s = %#v, want %#v", c.name, c.got, c.want)
+		}
+	}
+
+	for _, want := range []string{"<p>今日も朝から散歩に行った。<br/>\n帰りにパン屋に寄った。</p>", "<h2>午後</h2>", "<p>午後は昼寝をした。</p>"} {
+		if !strings.Contains(post.Content, want) {
+			t.Errorf("Content does not contain %q: %s", want, post.Content)
+		}
+	}
+	if post.Summary == "" {
+		t.Error("Summary is empty")
+	}
+
+	draft := posts[1]
+	if draft.Published {
+		t.Error("draft Published = true")
+	}
+	if draft.Author != "guest" {
+		t.Errorf("draft Author = %q, want guest", draft.Author)
+	}
+	// GMTの日時がない下書きは、前の記事の日時から求めたサイトのタイムゾーン（日本時間）で解釈する
+	if want := time.Date(2025, 4, 20, 10, 0, 0, 0, jstLocation); !draft.CreatedAt.Equal(want) {
+		t.Errorf("draft CreatedAt = %v, want %v", draft.CreatedAt, want)
+	}
+	if !strings.Contains(draft.Content, "<p>書きかけの記事です。</p>") || strings.Contains(draft.Content, "<p><!--") {
+		t.Errorf("draft Content = %q", draft.Content)
+	}
+}
+
+func TestWXRItemLocation(t *testing.T) {
+	cet := time.FixedZone("", 2*60*60)
+	tests := []struct {
+		name string
+		item wxrItem
+		want time.Time // 下書きの投稿日時（2025-04-20 10:00:00）の解釈
+	}{
+		{
+			name: "更新日時の組から求める",
+			item: wxrItem{PostModified: "2025-04-21 12:00:00", PostModifiedGMT: "2025-04-21 10:00:00"},
+			want: time.Date(2025, 4, 20, 10, 0, 0, 0, cet),
+		},
+		{
+			name: "コメントの日時の組から求める",
+			item: wxrItem{Comments: []wxrComment{{Date: "2025-04-21 03:00:00", DateGMT: "2025-04-21 10:00:00"}}},
+			want: time.Date(2025, 4, 20, 10, 0, 0, 0, time.FixedZone("", -7*60*60)),
+		},
+		{
+			name: "求められない場合は日本時間",
+			item: wxrItem{PostModified: "2025-04-21 12:00:00", PostModifiedGMT: "0000-00-00 00:00:00"},
+			want: time.Date(2025, 4, 20, 10, 0, 0, 0, jstLocation),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.item.PostDate = "2025-04-20 10:00:00"
+			tt.item.PostDateGMT = "0000-00-00 00:00:00"
+			loc := tt.item.location(jstLocation)
+			if got := wxrDate(tt.item.PostDateGMT, tt.item.PostDate, loc); !got.Equal(tt.want) {
+				t.Errorf("wxrDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWXRParserParseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		unsupported bool
+	}{
+		{"Atom", `<feed xmlns="http://www.w3.org/2005/Atom"></feed>`, true},
+		{"RSS", `<rss version="2.0"><channel><title>t</title><item><title>a</title></item></channel></rss>`, true},
+		{"壊れたXML", `<rss><channel><wp:wxr_version>1.2</wp:wxr_version><item><title>a</item>`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWXRParser().Parse(context.Background(), strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatal("Parse() error = nil")
+			}
+			if got := errors.Is(err, ErrUnsupportedFeed); got != tt.unsupported {
+				t.Errorf("errors.Is(err, ErrUnsupportedFeed) = %v, want %v (err = %v)", got, tt.unsupported, err)
+			}
+		})
+	}
+}
+
+func TestWXRParserParseWithCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewWXRParser().Parse(ctx, strings.NewReader(testWXR)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Parse() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWpautop(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"段落と改行", "一行目\r\n二行目\n\n三行目", "<p>一行目<br />\n二行目</p>\n<p>三行目</p>\n"},
+		{"ブロック要素", "<h2>見出し</h2>\n\n<ul>\n<li>a</li>\n</ul>", "<h2>見出し</h2>\n<ul>\n<li>a</li>\n</ul>\n"},
+		{"インライン要素", "<strong>強調</strong>です", "<p><strong>強調</strong>です</p>\n"},
+		{"空行を含むコードブロック", "前\n\n<pre><code>a\n\nb\n</code></pre>\n\n後", "<p>前</p>\n<pre><code>a\n\nb\n</code></pre>\n<p>後</p>\n"},
+		{"空行を含む入れ子のリスト", "<ul>\n<li>a\n<ul>\n<li>b</li>\n</ul>\n\n</li>\n</ul>", "<ul>\n<li>a\n<ul>\n<li>b</li>\n</ul>\n\n</li>\n</ul>\n"},
+		{"空行を含む表", "<table>\n<tr><td>a</td></tr>\n\n<tr><td>b</td></tr>\n</table>", "<table>\n<tr><td>a</td></tr>\n\n<tr><td>b</td></tr>\n</table>\n"},
+		{"閉じていないコードブロック", "<pre>a\n\nb", "<pre>a\n<p>b</p>\n"},
+		{"空", " \n\n ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wpautop(tt.content); got != tt.want {
+				t.Errorf("wpautop() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
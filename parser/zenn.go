@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// zennMeta はZennのページに埋め込まれたNext.jsの初期データ（__NEXT_DATA__）からメタデータを取り出します
+func zennMeta(doc *goquery.Document) articleMeta {
+	var meta articleMeta
+	var data any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(doc.Find("script#__NEXT_DATA__").First().Text())), &data); err != nil {
+		return meta
+	}
+	article := jsonObject(data, "props", "pageProps", "article")
+	if article == nil {
+		return meta
+	}
+
+	meta.postID = jsonString(article["slug"])
+	meta.title = jsonString(article["title"])
+	if t, err := parseDateString(jsonString(article["publishedAt"])); err == nil {
+		meta.date = t
+	}
+	// bodyUpdatedAtは本文の更新日時（いいねなどでは変わらない）
+	if t, err := parseDateString(jsonFirstString(article, "bodyUpdatedAt", "updatedAt")); err == nil {
+		meta.updated = t
+	}
+	if user := jsonObject(article, "user"); user != nil {
+		meta.author = jsonFirstString(user, "name", "username")
+	}
+	meta.tags = jsonNames(article["topics"], "displayName", "name")
+
+	meta.engagement = make(map[string]int)
+	if n, ok := jsonInt(article["likedCount"]); ok {
+		meta.engagement["likes"] = n
+	}
+	if n, ok := jsonInt(article["commentsCount"]); ok {
+		meta.engagement["comments"] = n
+	}
+	return meta
+}
@@ -9,17 +9,78 @@ import (
 
 // BlogPostはブログ記事を表現する構造体です。
 type BlogPost struct {
-	Title      string    // タイトル
-	Author     string    // 著者名
-	Content    string    // 本文
-	Summary    string    // 要約
-	Tags       []string  // タグ
-	Categories []string  // カテゴリ
-	CreatedAt  time.Time // 作成日時
-	UpdatedAt  time.Time // 更新日時
-	Published  bool      // 公開フラグ
-	Slug       string    // URL用スラッグ
-	FirstImage string    // 記事内で最初に登場する画像のURL
+	Title        string      `json:"title" yaml:"title"`                                     // タイトル
+	Subtitle     string      `json:"subtitle,omitempty" yaml:"subtitle,omitempty"`           // サブタイトル（副題）
+	Author       string      `json:"author,omitempty" yaml:"author,omitempty"`               // 著者名
+	Content      string      `json:"content" yaml:"content"`                                 // 本文
+	Summary      string      `json:"summary,omitempty" yaml:"summary,omitempty"`             // 要約
+	Tags         []string    `json:"tags,omitempty" yaml:"tags,omitempty"`                   // タグ
+	Categories   []string    `json:"categories,omitempty" yaml:"categories,omitempty"`       // カテゴリ
+	CreatedAt    time.Time   `json:"created_at,omitempty" yaml:"created_at,omitempty"`       // 作成日時
+	UpdatedAt    time.Time   `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`       // 更新日時
+	Published    bool        `json:"published" yaml:"published"`                             // 公開フラグ
+	Slug         string      `json:"slug,omitempty" yaml:"slug,omitempty"`                   // URL用スラッグ
+	PostID       string      `json:"post_id,omitempty" yaml:"post_id,omitempty"`             // ブログサービスでの記事ID（WordPressの投稿IDなど）
+	FirstImage   string      `json:"first_image,omitempty" yaml:"first_image,omitempty"`     // 記事内で最初に登場する画像のURL
+	URL          string      `json:"url,omitempty" yaml:"url,omitempty"`                     // 記事のパーマリンク
+	CanonicalURL string      `json:"canonical_url,omitempty" yaml:"canonical_url,omitempty"` // 正規URL（link[rel=canonical]）
+	SiteName     string      `json:"site_name,omitempty" yaml:"site_name,omitempty"`         // サイト（ブログ）名
+	Outline      []Heading   `json:"outline,omitempty" yaml:"outline,omitempty"`             // 見出しの階層構造（目次）
+	Embeds       []Embed     `json:"embeds,omitempty" yaml:"embeds,omitempty"`               // 埋め込みメディア（動画・SNS投稿など）
+	Comments     []Comment   `json:"comments,omitempty" yaml:"comments,omitempty"`           // 読者のコメント
+	PrevPost     *PostLink   `json:"prev_post,omitempty" yaml:"prev_post,omitempty"`         // 前の（古い）記事
+	NextPost     *PostLink   `json:"next_post,omitempty" yaml:"next_post,omitempty"`         // 次の（新しい）記事
+	RelatedPosts []PostLink  `json:"related_posts,omitempty" yaml:"related_posts,omitempty"` // 関連記事
+	Engagement   *Engagement `json:"engagement,omitempty" yaml:"engagement,omitempty"`       // いいね・コメント・リブログの数（取得できない場合はnil）
+	Stats        Stats       `json:"stats" yaml:"stats"`                                     // 本文の統計情報
+}
+
+// Heading は本文の見出しを表現する構造体です。
+type Heading struct {
+	Level    int       `json:"level" yaml:"level"`                           // 見出しレベル（1〜6）
+	Text     string    `json:"text" yaml:"text"`                             // 見出しのテキスト
+	ID       string    `json:"id,omitempty" yaml:"id,omitempty"`             // アンカー用のID
+	Children []Heading `json:"children,omitempty" yaml:"children,omitempty"` // 下位の見出し
+}
+
+// Embed は本文に埋め込まれた外部メディアを表現する構造体です。
+type Embed struct {
+	Provider string `json:"provider" yaml:"provider"`         // 提供元（youtube, twitter, instagram, spotify, ameblo）
+	ID       string `json:"id,omitempty" yaml:"id,omitempty"` // 提供元での動画・投稿などのID
+	URL      string `json:"url" yaml:"url"`                   // 埋め込まれたメディアのURL
+}
+
+// Comment は記事に寄せられたコメントを表現する構造体です。
+type Comment struct {
+	Author    string    `json:"author,omitempty" yaml:"author,omitempty"`         // 投稿者名
+	AuthorURL string    `json:"author_url,omitempty" yaml:"author_url,omitempty"` // 投稿者のWebサイトURL
+	Body      string    `json:"body" yaml:"body"`                                 // 本文（改行を含むテキスト）
+	CreatedAt time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"` // 投稿日時
+	Replies   []Comment `json:"replies,omitempty" yaml:"replies,omitempty"`       // 返信コメント
+}
+
+// Stats は本文の統計情報を表現する構造体です。
+type Stats struct {
+	Characters         int `json:"characters" yaml:"characters"`                     // 文字数（HTMLタグ・空白を除く）
+	Words              int `json:"words" yaml:"words"`                               // 単語数（形態素解析による。記号を除く）
+	Sentences          int `json:"sentences" yaml:"sentences"`                       // 文の数
+	Paragraphs         int `json:"paragraphs" yaml:"paragraphs"`                     // 段落の数
+	Images             int `json:"images" yaml:"images"`                             // 画像の数
+	ReadingTimeSeconds int `json:"reading_time_seconds" yaml:"reading_time_seconds"` // 読了時間の目安（秒）
+}
+
+// Engagement は記事への反応の数を表現する構造体です。
+// ページ上で取得できなかった項目は0になります。
+type Engagement struct {
+	Likes    int `json:"likes" yaml:"likes"`       // いいね・スターの数
+	Comments int `json:"comments" yaml:"comments"` // コメント数
+	Shares   int `json:"shares" yaml:"shares"`     // リブログ・シェア・ブックマークの数
+}
+
+// PostLink は他の記事へのリンクを表現する構造体です。
+type PostLink struct {
+	Title string `json:"title,omitempty" yaml:"title,omitempty"` // 記事タイトル
+	URL   string `json:"url" yaml:"url"`                         // 記事のURL
 }
 
 // SetSlug はTitleからSlugを生成してセットするメソッド
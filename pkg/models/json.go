@@ -0,0 +1,59 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JSONSchema はBlogPostのJSON表現を定義するJSON Schema（draft 2020-12）です。
+//
+//go:embed blogpost.schema.json
+var JSONSchema []byte
+
+// MarshalJSON はBlogPostをJSONに変換します。
+// 作成日時・更新日時がゼロ値の場合は「0001-01-01T00:00:00Z」を出力せず、項目を省略します。
+func (b BlogPost) MarshalJSON() ([]byte, error) {
+	type alias BlogPost
+	return json.Marshal(struct {
+		alias
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}{
+		alias:     alias(b),
+		CreatedAt: nonZeroTime(b.CreatedAt),
+		UpdatedAt: nonZeroTime(b.UpdatedAt),
+	})
+}
+
+// MarshalJSON はCommentをJSONに変換します。
+// 投稿日時がゼロ値の場合は項目を省略します。
+func (c Comment) MarshalJSON() ([]byte, error) {
+	type alias Comment
+	return json.Marshal(struct {
+		alias
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+	}{
+		alias:     alias(c),
+		CreatedAt: nonZeroTime(c.CreatedAt),
+	})
+}
+
+// FromJSON はMarshalJSONで出力したJSONからBlogPostを復元します。
+// 省略された日時はゼロ値になります。
+func FromJSON(data []byte) (*BlogPost, error) {
+	var post BlogPost
+	if err := json.Unmarshal(data, &post); err != nil {
+		return nil, fmt.Errorf("ブログ記事のJSONの読み込みに失敗しました: %w", err)
+	}
+	return &post, nil
+}
+
+// nonZeroTime はゼロ値でない日時のポインタを返します（ゼロ値の場合はnil）
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalJSONOmitsZeroTime(t *testing.T) {
+	post := BlogPost{
+		Title:    "タイトル",
+		Content:  "<p>本文</p>",
+		Comments: []Comment{{Body: "コメント"}},
+	}
+	data, err := json.Marshal(post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(data)
+	for _, unwanted := range []string{"0001-01-01", `"created_at"`, `"updated_at"`} {
+		if strings.Contains(s, unwanted) {
+			t.Errorf("json.Marshal() = %s, should not contain %s", s, unwanted)
+		}
+	}
+
+	post.CreatedAt = time.Date(2024, 5, 22, 12, 39, 1, 0, time.FixedZone("JST", 9*3600))
+	data, err = json.Marshal(&post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"created_at":"2024-05-22T12:39:01+09:00"`) {
+		t.Errorf("json.Marshal() = %s, want created_at", data)
+	}
+}
+
+func TestFromJSONRoundTrip(t *testing.T) {
+	created := time.Date(2025, 4, 13, 9, 18, 5, 0, time.UTC)
+	want := &BlogPost{
+		Title:        "『ルーティーン』",
+		Content:      "<p>本文</p>",
+		Tags:         []string{"認知症"},
+		CreatedAt:    created,
+		Published:    true,
+		URL:          "https://ameblo.jp/macb2b37/entry-12887862927.html",
+		Outline:      []Heading{{Level: 2, Text: "見出し", ID: "見出し", Children: []Heading{{Level: 3, Text: "小見出し"}}}},
+		Embeds:       []Embed{{Provider: "youtube", ID: "abc", URL: "https://www.youtube.com/watch?v=abc"}},
+		Comments:     []Comment{{Author: "名無し", Body: "a\nb", CreatedAt: created, Replies: []Comment{{Body: "返信"}}}},
+		PrevPost:     &PostLink{Title: "前", URL: "https://example.com/1"},
+		RelatedPosts: []PostLink{{URL: "https://example.com/2"}},
+		Engagement:   &Engagement{Likes: 3},
+		Stats:        Stats{Characters: 2, Words: 1, Sentences: 1, Paragraphs: 1, ReadingTimeSeconds: 1},
+	}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromJSON() = %+v, want %+v", got, want)
+	}
+
+	if _, err := FromJSON([]byte(`{"title":`)); err == nil {
+		t.Error("FromJSON() with invalid JSON should return error")
+	}
+}
+
+func TestJSONSchemaMatchesModel(t *testing.T) {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(JSONSchema, &schema); err != nil {
+		t.Fatalf("JSONSchema is not valid JSON: %v", err)
+	}
+
+	tests := []struct {
+		def string
+		typ reflect.Type
+	}{
+		{"", reflect.TypeOf(BlogPost{})},
+		{"heading", reflect.TypeOf(Heading{})},
+		{"embed", reflect.TypeOf(Embed{})},
+		{"comment", reflect.TypeOf(Comment{})},
+		{"postLink", reflect.TypeOf(PostLink{})},
+		{"engagement", reflect.TypeOf(Engagement{})},
+		{"stats", reflect.TypeOf(Stats{})},
+	}
+	for _, tt := range tests {
+		properties := schema.Properties
+		if tt.def != "" {
+			properties = schema.Defs[tt.def].Properties
+		}
+		if got, want := sortedKeys(properties), jsonFieldNames(tt.typ); !reflect.DeepEqual(got, want) {
+			t.Errorf("schema properties of %s = %v, want %v", tt.typ.Name(), got, want)
+		}
+	}
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonFieldNames(typ reflect.Type) []string {
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
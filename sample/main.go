@@ -41,7 +41,8 @@ func run() error {
 		fmt.Printf("作成日時: %s\n", post.CreatedAt)
 		fmt.Printf("カテゴリ: %v\n", post.Categories)
 		fmt.Printf("タグ: %v\n", post.Tags)
-		fmt.Printf("本文の長さ: %d文字\n", len(post.Content))
+		fmt.Printf("本文の長さ: %d文字（%dバイト）\n", post.Stats.Characters, len(post.Content))
+		fmt.Printf("読了時間の目安: %d秒\n", post.Stats.ReadingTimeSeconds)
 		fmt.Printf("最初の画像: %s\n", post.FirstImage)
 		fmt.Println("----------------------")
 	}